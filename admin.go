@@ -0,0 +1,104 @@
+package main
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+	channelzsvc "google.golang.org/grpc/channelz/service"
+	"google.golang.org/grpc/reflection"
+)
+
+// makeAdminServer makes an HTTP server for introspection of the running
+// process, which serves pprof, version, configuration and metrics, if the
+// handler is set, over HTTP, and gRPC channelz service over HTTP/2 on the
+// same listener.
+func makeAdminServer(metrics http.Handler, tlsCfg *tls.Config) (*http.Server, error) {
+	srv := grpc.NewServer()
+	channelzsvc.RegisterChannelzServiceToServer(srv)
+	reflection.Register(srv)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("GET /version", func(w http.ResponseWriter, _ *http.Request) { writeJSON(w, makeVersionInfo()) })
+	mux.HandleFunc("GET /config", func(w http.ResponseWriter, _ *http.Request) { writeJSON(w, opts) })
+	if metrics != nil {
+		mux.Handle("GET /metrics", metrics)
+	}
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			srv.ServeHTTP(w, r)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+
+	h2s := &http2.Server{}
+	httpSrv := &http.Server{
+		Handler:           h2c.NewHandler(h, h2s),
+		TLSConfig:         tlsCfg,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	httpSrv.RegisterOnShutdown(srv.Stop)
+
+	if err := http2.ConfigureServer(httpSrv, h2s); err != nil {
+		return nil, fmt.Errorf("configure http2: %w", err)
+	}
+
+	return httpSrv, nil
+}
+
+// versionInfo describes the build of the running binary.
+type versionInfo struct {
+	Version   string            `json:"version"`
+	GoVersion string            `json:"go_version"`
+	Path      string            `json:"path,omitempty"`
+	Settings  map[string]string `json:"settings,omitempty"`
+	Deps      map[string]string `json:"deps,omitempty"`
+}
+
+func makeVersionInfo() versionInfo {
+	info := versionInfo{Version: getVersion(), GoVersion: runtime.Version()}
+
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+
+	info.Path = bi.Path
+	info.Settings = make(map[string]string, len(bi.Settings))
+	for _, s := range bi.Settings {
+		info.Settings[s.Key] = s.Value
+	}
+	info.Deps = make(map[string]string, len(bi.Deps))
+	for _, dep := range bi.Deps {
+		version := dep.Version
+		if dep.Replace != nil {
+			version = fmt.Sprintf("%s => %s %s", dep.Version, dep.Replace.Path, dep.Replace.Version)
+		}
+		info.Deps[dep.Path] = version
+	}
+
+	return info
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
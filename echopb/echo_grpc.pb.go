@@ -19,7 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	EchoService_Echo_FullMethodName = "/grpc_echo.v1.EchoService/Echo"
+	EchoService_Echo_FullMethodName       = "/grpc_echo.v1.EchoService/Echo"
+	EchoService_EchoStream_FullMethodName = "/grpc_echo.v1.EchoService/EchoStream"
+	EchoService_Collect_FullMethodName    = "/grpc_echo.v1.EchoService/Collect"
+	EchoService_EchoBidi_FullMethodName   = "/grpc_echo.v1.EchoService/EchoBidi"
 )
 
 // EchoServiceClient is the client API for EchoService service.
@@ -27,6 +30,9 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type EchoServiceClient interface {
 	Echo(ctx context.Context, in *EchoRequest, opts ...grpc.CallOption) (*EchoResponse, error)
+	EchoStream(ctx context.Context, in *EchoStreamRequest, opts ...grpc.CallOption) (EchoService_EchoStreamClient, error)
+	Collect(ctx context.Context, opts ...grpc.CallOption) (EchoService_CollectClient, error)
+	EchoBidi(ctx context.Context, opts ...grpc.CallOption) (EchoService_EchoBidiClient, error)
 }
 
 type echoServiceClient struct {
@@ -46,11 +52,111 @@ func (c *echoServiceClient) Echo(ctx context.Context, in *EchoRequest, opts ...g
 	return out, nil
 }
 
+func (c *echoServiceClient) EchoStream(ctx context.Context, in *EchoStreamRequest, opts ...grpc.CallOption) (EchoService_EchoStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &EchoService_ServiceDesc.Streams[0], EchoService_EchoStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &echoServiceEchoStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EchoService_EchoStreamClient interface {
+	Recv() (*EchoResponse, error)
+	grpc.ClientStream
+}
+
+type echoServiceEchoStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *echoServiceEchoStreamClient) Recv() (*EchoResponse, error) {
+	m := new(EchoResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *echoServiceClient) Collect(ctx context.Context, opts ...grpc.CallOption) (EchoService_CollectClient, error) {
+	stream, err := c.cc.NewStream(ctx, &EchoService_ServiceDesc.Streams[1], EchoService_Collect_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &echoServiceCollectClient{stream}
+	return x, nil
+}
+
+type EchoService_CollectClient interface {
+	Send(*EchoRequest) error
+	CloseAndRecv() (*CollectResponse, error)
+	grpc.ClientStream
+}
+
+type echoServiceCollectClient struct {
+	grpc.ClientStream
+}
+
+func (x *echoServiceCollectClient) Send(m *EchoRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *echoServiceCollectClient) CloseAndRecv() (*CollectResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(CollectResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *echoServiceClient) EchoBidi(ctx context.Context, opts ...grpc.CallOption) (EchoService_EchoBidiClient, error) {
+	stream, err := c.cc.NewStream(ctx, &EchoService_ServiceDesc.Streams[2], EchoService_EchoBidi_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &echoServiceEchoBidiClient{stream}
+	return x, nil
+}
+
+type EchoService_EchoBidiClient interface {
+	Send(*EchoRequest) error
+	Recv() (*EchoResponse, error)
+	grpc.ClientStream
+}
+
+type echoServiceEchoBidiClient struct {
+	grpc.ClientStream
+}
+
+func (x *echoServiceEchoBidiClient) Send(m *EchoRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *echoServiceEchoBidiClient) Recv() (*EchoResponse, error) {
+	m := new(EchoResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // EchoServiceServer is the server API for EchoService service.
 // All implementations must embed UnimplementedEchoServiceServer
 // for forward compatibility
 type EchoServiceServer interface {
 	Echo(context.Context, *EchoRequest) (*EchoResponse, error)
+	EchoStream(*EchoStreamRequest, EchoService_EchoStreamServer) error
+	Collect(EchoService_CollectServer) error
+	EchoBidi(EchoService_EchoBidiServer) error
 	mustEmbedUnimplementedEchoServiceServer()
 }
 
@@ -61,6 +167,15 @@ type UnimplementedEchoServiceServer struct {
 func (UnimplementedEchoServiceServer) Echo(context.Context, *EchoRequest) (*EchoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Echo not implemented")
 }
+func (UnimplementedEchoServiceServer) EchoStream(*EchoStreamRequest, EchoService_EchoStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method EchoStream not implemented")
+}
+func (UnimplementedEchoServiceServer) Collect(EchoService_CollectServer) error {
+	return status.Errorf(codes.Unimplemented, "method Collect not implemented")
+}
+func (UnimplementedEchoServiceServer) EchoBidi(EchoService_EchoBidiServer) error {
+	return status.Errorf(codes.Unimplemented, "method EchoBidi not implemented")
+}
 func (UnimplementedEchoServiceServer) mustEmbedUnimplementedEchoServiceServer() {}
 
 // UnsafeEchoServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -92,6 +207,79 @@ func _EchoService_Echo_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EchoService_EchoStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EchoStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EchoServiceServer).EchoStream(m, &echoServiceEchoStreamServer{stream})
+}
+
+type EchoService_EchoStreamServer interface {
+	Send(*EchoResponse) error
+	grpc.ServerStream
+}
+
+type echoServiceEchoStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *echoServiceEchoStreamServer) Send(m *EchoResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _EchoService_Collect_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EchoServiceServer).Collect(&echoServiceCollectServer{stream})
+}
+
+type EchoService_CollectServer interface {
+	SendAndClose(*CollectResponse) error
+	Recv() (*EchoRequest, error)
+	grpc.ServerStream
+}
+
+type echoServiceCollectServer struct {
+	grpc.ServerStream
+}
+
+func (x *echoServiceCollectServer) SendAndClose(m *CollectResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *echoServiceCollectServer) Recv() (*EchoRequest, error) {
+	m := new(EchoRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _EchoService_EchoBidi_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EchoServiceServer).EchoBidi(&echoServiceEchoBidiServer{stream})
+}
+
+type EchoService_EchoBidiServer interface {
+	Send(*EchoResponse) error
+	Recv() (*EchoRequest, error)
+	grpc.ServerStream
+}
+
+type echoServiceEchoBidiServer struct {
+	grpc.ServerStream
+}
+
+func (x *echoServiceEchoBidiServer) Send(m *EchoResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *echoServiceEchoBidiServer) Recv() (*EchoRequest, error) {
+	m := new(EchoRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // EchoService_ServiceDesc is the grpc.ServiceDesc for EchoService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -104,6 +292,23 @@ var EchoService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _EchoService_Echo_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "EchoStream",
+			Handler:       _EchoService_EchoStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Collect",
+			Handler:       _EchoService_Collect_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "EchoBidi",
+			Handler:       _EchoService_EchoBidi_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "echopb/echo.proto",
 }
@@ -0,0 +1,199 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: echopb/echo.proto
+
+package echopbconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	echopb "github.com/Semior001/grpc-echo/echopb"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// EchoServiceName is the fully-qualified name of the EchoService service.
+	EchoServiceName = "grpc_echo.v1.EchoService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// EchoServiceEchoProcedure is the fully-qualified name of the EchoService's Echo RPC.
+	EchoServiceEchoProcedure = "/grpc_echo.v1.EchoService/Echo"
+	// EchoServiceEchoStreamProcedure is the fully-qualified name of the EchoService's EchoStream RPC.
+	EchoServiceEchoStreamProcedure = "/grpc_echo.v1.EchoService/EchoStream"
+	// EchoServiceCollectProcedure is the fully-qualified name of the EchoService's Collect RPC.
+	EchoServiceCollectProcedure = "/grpc_echo.v1.EchoService/Collect"
+	// EchoServiceEchoBidiProcedure is the fully-qualified name of the EchoService's EchoBidi RPC.
+	EchoServiceEchoBidiProcedure = "/grpc_echo.v1.EchoService/EchoBidi"
+)
+
+// These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
+var (
+	echoServiceServiceDescriptor          = echopb.File_echopb_echo_proto.Services().ByName("EchoService")
+	echoServiceEchoMethodDescriptor       = echoServiceServiceDescriptor.Methods().ByName("Echo")
+	echoServiceEchoStreamMethodDescriptor = echoServiceServiceDescriptor.Methods().ByName("EchoStream")
+	echoServiceCollectMethodDescriptor    = echoServiceServiceDescriptor.Methods().ByName("Collect")
+	echoServiceEchoBidiMethodDescriptor   = echoServiceServiceDescriptor.Methods().ByName("EchoBidi")
+)
+
+// EchoServiceClient is a client for the grpc_echo.v1.EchoService service.
+type EchoServiceClient interface {
+	Echo(context.Context, *connect.Request[echopb.EchoRequest]) (*connect.Response[echopb.EchoResponse], error)
+	EchoStream(context.Context, *connect.Request[echopb.EchoStreamRequest]) (*connect.ServerStreamForClient[echopb.EchoResponse], error)
+	Collect(context.Context) *connect.ClientStreamForClient[echopb.EchoRequest, echopb.CollectResponse]
+	EchoBidi(context.Context) *connect.BidiStreamForClient[echopb.EchoRequest, echopb.EchoResponse]
+}
+
+// NewEchoServiceClient constructs a client for the grpc_echo.v1.EchoService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewEchoServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) EchoServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &echoServiceClient{
+		echo: connect.NewClient[echopb.EchoRequest, echopb.EchoResponse](
+			httpClient,
+			baseURL+EchoServiceEchoProcedure,
+			connect.WithSchema(echoServiceEchoMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		echoStream: connect.NewClient[echopb.EchoStreamRequest, echopb.EchoResponse](
+			httpClient,
+			baseURL+EchoServiceEchoStreamProcedure,
+			connect.WithSchema(echoServiceEchoStreamMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		collect: connect.NewClient[echopb.EchoRequest, echopb.CollectResponse](
+			httpClient,
+			baseURL+EchoServiceCollectProcedure,
+			connect.WithSchema(echoServiceCollectMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		echoBidi: connect.NewClient[echopb.EchoRequest, echopb.EchoResponse](
+			httpClient,
+			baseURL+EchoServiceEchoBidiProcedure,
+			connect.WithSchema(echoServiceEchoBidiMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// echoServiceClient implements EchoServiceClient.
+type echoServiceClient struct {
+	echo       *connect.Client[echopb.EchoRequest, echopb.EchoResponse]
+	echoStream *connect.Client[echopb.EchoStreamRequest, echopb.EchoResponse]
+	collect    *connect.Client[echopb.EchoRequest, echopb.CollectResponse]
+	echoBidi   *connect.Client[echopb.EchoRequest, echopb.EchoResponse]
+}
+
+// Echo calls grpc_echo.v1.EchoService.Echo.
+func (c *echoServiceClient) Echo(ctx context.Context, req *connect.Request[echopb.EchoRequest]) (*connect.Response[echopb.EchoResponse], error) {
+	return c.echo.CallUnary(ctx, req)
+}
+
+// EchoStream calls grpc_echo.v1.EchoService.EchoStream.
+func (c *echoServiceClient) EchoStream(ctx context.Context, req *connect.Request[echopb.EchoStreamRequest]) (*connect.ServerStreamForClient[echopb.EchoResponse], error) {
+	return c.echoStream.CallServerStream(ctx, req)
+}
+
+// Collect calls grpc_echo.v1.EchoService.Collect.
+func (c *echoServiceClient) Collect(ctx context.Context) *connect.ClientStreamForClient[echopb.EchoRequest, echopb.CollectResponse] {
+	return c.collect.CallClientStream(ctx)
+}
+
+// EchoBidi calls grpc_echo.v1.EchoService.EchoBidi.
+func (c *echoServiceClient) EchoBidi(ctx context.Context) *connect.BidiStreamForClient[echopb.EchoRequest, echopb.EchoResponse] {
+	return c.echoBidi.CallBidiStream(ctx)
+}
+
+// EchoServiceHandler is an implementation of the grpc_echo.v1.EchoService service.
+type EchoServiceHandler interface {
+	Echo(context.Context, *connect.Request[echopb.EchoRequest]) (*connect.Response[echopb.EchoResponse], error)
+	EchoStream(context.Context, *connect.Request[echopb.EchoStreamRequest], *connect.ServerStream[echopb.EchoResponse]) error
+	Collect(context.Context, *connect.ClientStream[echopb.EchoRequest]) (*connect.Response[echopb.CollectResponse], error)
+	EchoBidi(context.Context, *connect.BidiStream[echopb.EchoRequest, echopb.EchoResponse]) error
+}
+
+// NewEchoServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewEchoServiceHandler(svc EchoServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	echoServiceEchoHandler := connect.NewUnaryHandler(
+		EchoServiceEchoProcedure,
+		svc.Echo,
+		connect.WithSchema(echoServiceEchoMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	echoServiceEchoStreamHandler := connect.NewServerStreamHandler(
+		EchoServiceEchoStreamProcedure,
+		svc.EchoStream,
+		connect.WithSchema(echoServiceEchoStreamMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	echoServiceCollectHandler := connect.NewClientStreamHandler(
+		EchoServiceCollectProcedure,
+		svc.Collect,
+		connect.WithSchema(echoServiceCollectMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	echoServiceEchoBidiHandler := connect.NewBidiStreamHandler(
+		EchoServiceEchoBidiProcedure,
+		svc.EchoBidi,
+		connect.WithSchema(echoServiceEchoBidiMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/grpc_echo.v1.EchoService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case EchoServiceEchoProcedure:
+			echoServiceEchoHandler.ServeHTTP(w, r)
+		case EchoServiceEchoStreamProcedure:
+			echoServiceEchoStreamHandler.ServeHTTP(w, r)
+		case EchoServiceCollectProcedure:
+			echoServiceCollectHandler.ServeHTTP(w, r)
+		case EchoServiceEchoBidiProcedure:
+			echoServiceEchoBidiHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedEchoServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedEchoServiceHandler struct{}
+
+func (UnimplementedEchoServiceHandler) Echo(context.Context, *connect.Request[echopb.EchoRequest]) (*connect.Response[echopb.EchoResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_echo.v1.EchoService.Echo is not implemented"))
+}
+
+func (UnimplementedEchoServiceHandler) EchoStream(context.Context, *connect.Request[echopb.EchoStreamRequest], *connect.ServerStream[echopb.EchoResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("grpc_echo.v1.EchoService.EchoStream is not implemented"))
+}
+
+func (UnimplementedEchoServiceHandler) Collect(context.Context, *connect.ClientStream[echopb.EchoRequest]) (*connect.Response[echopb.CollectResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_echo.v1.EchoService.Collect is not implemented"))
+}
+
+func (UnimplementedEchoServiceHandler) EchoBidi(context.Context, *connect.BidiStream[echopb.EchoRequest, echopb.EchoResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("grpc_echo.v1.EchoService.EchoBidi is not implemented"))
+}
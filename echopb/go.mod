@@ -3,6 +3,7 @@ module github.com/Semior001/grpc-echo/echopb
 go 1.23.4
 
 require (
+	connectrpc.com/connect v1.17.0
 	google.golang.org/grpc v1.68.1
 	google.golang.org/protobuf v1.35.2
 )
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/Semior001/grpc-echo/echopb/echopbconnect"
+	"github.com/Semior001/grpc-echo/pkg/grpcx"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// httpHandlers are the handlers served by the HTTP server besides gRPC.
+type httpHandlers struct {
+	Health  healthpb.HealthServer
+	Connect echopbconnect.EchoServiceHandler
+	REST    http.Handler
+}
+
+// makeHTTPServer makes an HTTP server, which serves gRPC-Web, Connect and
+// REST, if they're enabled, over HTTP/2 and HTTP/1.1 on the connections,
+// demultiplexed by grpcx.DemuxListener, thus TLS is already terminated for
+// them. Health check and info page are served anyway.
+func makeHTTPServer(srv *httpGRPCServer, handlers httpHandlers) (*http.Server, error) {
+	mux := http.NewServeMux()
+	mux.Handle("GET /health", healthCheck(handlers.Health))
+	mux.Handle("GET /{$}", infoPage(srv.Server))
+
+	if opts.Connect {
+		path, h := echopbconnect.NewEchoServiceHandler(handlers.Connect,
+			connect.WithReadMaxBytes(1024*4), // 4KB, same as for gRPC
+			connect.WithSendMaxBytes(1024*4), // 4KB, same as for gRPC
+		)
+		mux.Handle(path, withGRPCContext(h))
+	}
+
+	if opts.REST {
+		mux.Handle("POST /v1/echo", withGRPCContext(handlers.REST))
+	}
+
+	var grpcWeb http.Handler
+	if opts.GRPCWeb.Enable {
+		grpcWeb = withCORS(
+			grpcx.GRPCWebText(grpcx.GRPCWeb(srv)),
+			opts.GRPCWeb.AllowedOrigins,
+			opts.GRPCWeb.AllowedHeaders,
+		)
+	}
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case grpcWeb != nil && isGRPCWebRequest(r):
+			grpcWeb.ServeHTTP(w, r)
+		case r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc"):
+			// gRPC calls on connections, which started with other requests;
+			// unlike the native transport, the HTTP one doesn't call tap handles
+			srv.ServeHTTP(w, r.WithContext(grpcx.WithGRPCTimeout(r.Context(), r.Header.Get("Grpc-Timeout"))))
+		default:
+			mux.ServeHTTP(w, r)
+		}
+	})
+
+	h2s := &http2.Server{
+		MaxConcurrentStreams: 1000,
+		IdleTimeout:          opts.Keepalive.MaxConnIdle,
+	}
+
+	httpSrv := &http.Server{
+		Handler:           h2c.NewHandler(grpcx.DemuxTLS(h), h2s),
+		ConnContext:       grpcx.DemuxConnContext,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	// configure the server to shut down HTTP/2 connections gracefully
+	if err := http2.ConfigureServer(httpSrv, h2s); err != nil {
+		return nil, fmt.Errorf("configure http2: %w", err)
+	}
+
+	return httpSrv, nil
+}
+
+// httpGRPCServer serves gRPC calls over the HTTP server and tracks them,
+// as gRPC server panics on graceful stop, if any of them is in flight.
+type httpGRPCServer struct {
+	*grpc.Server
+	inflight atomic.Int64
+}
+
+func (s *httpGRPCServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.inflight.Add(1)
+	defer s.inflight.Add(-1)
+	s.Server.ServeHTTP(w, r)
+}
+
+// wait waits for the calls served over the HTTP server to finish
+// and reports whether they did before the context is done.
+func (s *httpGRPCServer) wait(ctx context.Context) bool {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for s.inflight.Load() > 0 {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+	return true
+}
+
+// serveHTTP serves the HTTP server on the listener, with TLS, if secure is set.
+func serveHTTP(httpSrv *http.Server, lis net.Listener, secure bool) (err error) {
+	if secure {
+		// certificates are provided by the config
+		err = httpSrv.ServeTLS(lis, "", "")
+	} else {
+		err = httpSrv.Serve(lis)
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// healthCheck responds with the serving status of the service from
+// the "service" query parameter, or of the server overall, if it's empty,
+// as gRPC health check does.
+func healthCheck(healthSrv healthpb.HealthServer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, err := healthSrv.Check(r.Context(), &healthpb.HealthCheckRequest{
+			Service: r.URL.Query().Get("service"),
+		})
+		if err != nil {
+			http.Error(w, status.Convert(err).Message(), http.StatusNotFound)
+			return
+		}
+
+		code := http.StatusOK
+		if resp.Status != healthpb.HealthCheckResponse_SERVING {
+			code = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(code)
+		_, _ = fmt.Fprintln(w, resp.Status)
+	})
+}
+
+// infoPage responds with the version, the enabled protocols
+// and the registered gRPC services with their methods.
+func infoPage(srv *grpc.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		protocols := []string{"grpc"}
+		if opts.GRPCWeb.Enable {
+			protocols = append(protocols, "grpc-web")
+		}
+		if opts.Connect {
+			protocols = append(protocols, "connect")
+		}
+		if opts.REST {
+			protocols = append(protocols, "rest")
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = fmt.Fprintf(w, "grpc-echo %s\n\n", getVersion())
+		_, _ = fmt.Fprintf(w, "protocols: %s\n\n", strings.Join(protocols, ", "))
+		_, _ = fmt.Fprintln(w, "services:")
+
+		services := srv.GetServiceInfo()
+		for _, name := range slices.Sorted(maps.Keys(services)) {
+			methods := make([]string, 0, len(services[name].Methods))
+			for _, m := range services[name].Methods {
+				methods = append(methods, m.Name)
+			}
+			slices.Sort(methods)
+			_, _ = fmt.Fprintf(w, "  %s: %s\n", name, strings.Join(methods, ", "))
+		}
+	})
+}
+
+// withGRPCContext sets the peer and the incoming metadata to the request context,
+// so that gRPC handlers see the same information as for gRPC requests.
+func withGRPCContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(grpcx.HTTPContext(r)))
+	})
+}
+
+// isGRPCWebRequest checks whether the request is a gRPC-Web one
+// or a CORS preflight request for it, which asks for X-Grpc-Web header,
+// as gRPC-Web clients send it.
+func isGRPCWebRequest(r *http.Request) bool {
+	if r.Method == http.MethodOptions {
+		return r.Header.Get("Access-Control-Request-Method") != "" &&
+			slices.ContainsFunc(strings.Split(r.Header.Get("Access-Control-Request-Headers"), ","), func(h string) bool {
+				return strings.EqualFold(strings.TrimSpace(h), "x-grpc-web")
+			})
+	}
+	return strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc-web")
+}
+
+// withCORS allows cross-origin requests from the listed origins with the
+// listed headers, "*" allows any of them. Preflight requests are answered
+// right away, all response headers are exposed to the browser, including
+// the custom ones, requested by the client.
+func withCORS(next http.Handler, origins, headers []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		allowed := origin != "" && (slices.Contains(origins, "*") || slices.Contains(origins, origin))
+		w.Header().Add("Vary", "Origin")
+
+		if r.Method == http.MethodOptions {
+			if allowed {
+				allowHeaders := strings.Join(headers, ", ")
+				if slices.Contains(headers, "*") {
+					allowHeaders = r.Header.Get("Access-Control-Request-Headers")
+				}
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				w.Header().Set("Access-Control-Allow-Methods", http.MethodPost)
+				w.Header().Set("Access-Control-Allow-Headers", allowHeaders)
+				w.Header().Set("Access-Control-Max-Age", "600")
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if !allowed {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		next.ServeHTTP(&exposeHeadersWriter{ResponseWriter: w}, r)
+	})
+}
+
+// exposeHeadersWriter lists the response headers in Access-Control-Expose-Headers
+// right before they are sent. Wildcard can't be used instead, as it's not
+// respected for requests with credentials.
+type exposeHeadersWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *exposeHeadersWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		h := w.Header()
+		names := make([]string, 0, len(h))
+		for k := range h {
+			if !strings.HasPrefix(k, "Access-Control-") && k != "Vary" {
+				names = append(names, k)
+			}
+		}
+		slices.Sort(names)
+		h.Set("Access-Control-Expose-Headers", strings.Join(names, ", "))
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *exposeHeadersWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *exposeHeadersWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *exposeHeadersWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
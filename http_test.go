@@ -0,0 +1,617 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/Semior001/grpc-echo/echopb"
+	"github.com/Semior001/grpc-echo/echopb/echopbconnect"
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc"
+	channelzpb "google.golang.org/grpc/channelz/grpc_channelz_v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestHTTP_GRPCWeb(t *testing.T) {
+	port, conn := setup(t, "--grpc-web.enable", "--grpc-web.allowed-origin", "https://example.com")
+	defer conn.Close()
+	waitForServerUp(t, conn) // native gRPC must keep working on the same port
+
+	url := fmt.Sprintf("http://localhost:%d/grpc_echo.v1.EchoService/Echo", port)
+
+	for _, contentType := range []string{"application/grpc-web+proto", "application/grpc-web-text+proto"} {
+		t.Run(contentType, func(t *testing.T) {
+			text := strings.HasPrefix(contentType, "application/grpc-web-text")
+
+			msg, err := proto.Marshal(&echopb.EchoRequest{Ping: "hello"})
+			assert(t, err == nil, "failed to marshal request: %v", err)
+
+			body := makeWebFrame(0, msg)
+			if text {
+				body = []byte(base64.StdEncoding.EncodeToString(body))
+			}
+
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+			assert(t, err == nil, "failed to make request: %v", err)
+			req.Header.Set("Content-Type", contentType)
+			req.Header.Set("X-Grpc-Web", "1")
+			req.Header.Set("Origin", "https://example.com")
+
+			resp, err := http.DefaultClient.Do(req)
+			assert(t, err == nil, "failed to do request: %v", err)
+			defer resp.Body.Close()
+
+			assert(t, resp.ProtoMajor == 1, "unexpected protocol: %s", resp.Proto)
+			assert(t, resp.StatusCode == http.StatusOK, "unexpected status: %d", resp.StatusCode)
+			assert(t, resp.Header.Get("Access-Control-Allow-Origin") == "https://example.com",
+				"unexpected allowed origin: %q", resp.Header.Get("Access-Control-Allow-Origin"))
+
+			respBody, err := io.ReadAll(resp.Body)
+			assert(t, err == nil, "failed to read response: %v", err)
+			if text {
+				respBody = decodeWebText(t, string(respBody))
+			}
+
+			frames := parseWebFrames(t, respBody)
+			assert(t, len(frames) == 2, "unexpected number of frames: %d", len(frames))
+			assert(t, frames[0].flags == 0, "first frame must be a data frame: %+v", frames[0])
+			assert(t, frames[1].flags == 0x80, "second frame must be a trailer frame: %+v", frames[1])
+
+			echoResp := &echopb.EchoResponse{}
+			err = proto.Unmarshal(frames[0].payload, echoResp)
+			assert(t, err == nil, "failed to unmarshal response: %v", err)
+			assert(t, echoResp.Body == "hello", "unexpected response body: %+v", echoResp.Body)
+			assert(t, echoResp.Headers["x-grpc-web"] == "1", "unexpected headers: %+v", echoResp.Headers)
+
+			assert(t, strings.Contains(strings.ToLower(string(frames[1].payload)), "grpc-status: 0"),
+				"unexpected trailers: %q", frames[1].payload)
+		})
+	}
+
+	t.Run("cors preflight", func(t *testing.T) {
+		for origin, allowed := range map[string]bool{"https://example.com": true, "https://evil.com": false} {
+			req, err := http.NewRequest(http.MethodOptions, url, http.NoBody)
+			assert(t, err == nil, "failed to make request: %v", err)
+			req.Header.Set("Origin", origin)
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			req.Header.Set("Access-Control-Request-Headers", "content-type,x-grpc-web")
+
+			resp, err := http.DefaultClient.Do(req)
+			assert(t, err == nil, "failed to do request: %v", err)
+			_ = resp.Body.Close()
+
+			allowedOrigin := resp.Header.Get("Access-Control-Allow-Origin")
+			assert(t, allowed == (allowedOrigin == origin), "unexpected allowed origin for %s: %q", origin, allowedOrigin)
+		}
+	})
+}
+
+func TestHTTP_GRPCWebStream(t *testing.T) {
+	port, conn := setup(t, "--grpc-web.enable", "--grpc-web.allowed-origin", "https://example.com")
+	defer conn.Close()
+	waitForServerUp(t, conn)
+
+	// call makes a gRPC-Web call and returns the response with the decoded frames
+	call := func(t *testing.T, method, contentType string, req proto.Message) (*http.Response, []webFrame) {
+		msg, err := proto.Marshal(req)
+		assert(t, err == nil, "failed to marshal request: %v", err)
+
+		body := makeWebFrame(0, msg)
+		text := strings.HasPrefix(contentType, "application/grpc-web-text")
+		if text {
+			body = []byte(base64.StdEncoding.EncodeToString(body))
+		}
+
+		httpReq, err := http.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:%d%s", port, method), bytes.NewReader(body))
+		assert(t, err == nil, "failed to make request: %v", err)
+		httpReq.Header.Set("Content-Type", contentType)
+		httpReq.Header.Set("X-Grpc-Web", "1")
+		httpReq.Header.Set("Origin", "https://example.com")
+
+		resp, err := http.DefaultClient.Do(httpReq)
+		assert(t, err == nil, "failed to do request: %v", err)
+		defer resp.Body.Close()
+		assert(t, resp.StatusCode == http.StatusOK, "unexpected status: %d", resp.StatusCode)
+		assert(t, resp.Header.Get("Content-Type") == contentType, "unexpected content type: %q", resp.Header.Get("Content-Type"))
+
+		respBody, err := io.ReadAll(resp.Body)
+		assert(t, err == nil, "failed to read response: %v", err)
+		if text {
+			respBody = decodeWebText(t, string(respBody))
+		}
+		return resp, parseWebFrames(t, respBody)
+	}
+
+	for _, contentType := range []string{"application/grpc-web+proto", "application/grpc-web-text+proto"} {
+		t.Run(contentType, func(t *testing.T) {
+			_, frames := call(t, "/grpc_echo.v1.EchoService/EchoStream", contentType, &echopb.EchoStreamRequest{
+				Ping:     "hello",
+				Count:    3,
+				Interval: durationpb.New(10 * time.Millisecond),
+			})
+			assert(t, len(frames) == 4, "unexpected number of frames: %d", len(frames))
+
+			for i, frame := range frames[:3] {
+				assert(t, frame.flags == 0, "frame %d must be a data frame: %+v", i, frame)
+				resp := &echopb.EchoResponse{}
+				err := proto.Unmarshal(frame.payload, resp)
+				assert(t, err == nil, "failed to unmarshal response: %v", err)
+				assert(t, resp.Seq == uint64(i+1), "unexpected seq: %d", resp.Seq)
+				assert(t, resp.Body == "hello", "unexpected response body: %+v", resp.Body)
+			}
+
+			assert(t, frames[3].flags == 0x80, "last frame must be a trailer frame: %+v", frames[3])
+			assert(t, strings.Contains(string(frames[3].payload), "grpc-status: 0\r\n"),
+				"unexpected trailers: %q", frames[3].payload)
+		})
+	}
+
+	t.Run("health", func(t *testing.T) {
+		_, frames := call(t, "/grpc.health.v1.Health/Check", "application/grpc-web+proto", &healthpb.HealthCheckRequest{})
+		assert(t, len(frames) == 2, "unexpected number of frames: %d", len(frames))
+
+		resp := &healthpb.HealthCheckResponse{}
+		err := proto.Unmarshal(frames[0].payload, resp)
+		assert(t, err == nil, "failed to unmarshal response: %v", err)
+		assert(t, resp.Status == healthpb.HealthCheckResponse_SERVING, "unexpected status: %v", resp.Status)
+	})
+
+	t.Run("error with custom metadata", func(t *testing.T) {
+		resp, frames := call(t, "/grpc_echo.v1.EchoService/Echo", "application/grpc-web+proto", &echopb.EchoRequest{
+			ResponseHeaders:  map[string]string{"x-resp": "val"},
+			ResponseTrailers: map[string]string{"x-trail": "val"},
+			Status:           &echopb.Status{Code: uint32(codes.NotFound), Message: "not here"},
+		})
+		assert(t, resp.Header.Get("X-Resp") == "val", "unexpected headers: %v", resp.Header)
+		assert(t, strings.Contains(resp.Header.Get("Access-Control-Expose-Headers"), "X-Resp"),
+			"custom header must be exposed: %q", resp.Header.Get("Access-Control-Expose-Headers"))
+
+		assert(t, len(frames) == 1 && frames[0].flags == 0x80, "unexpected frames: %+v", frames)
+		trailers := string(frames[0].payload)
+		for _, want := range []string{"grpc-status: 5\r\n", "grpc-message: not here\r\n", "x-trail: val\r\n"} {
+			assert(t, strings.Contains(trailers, want), "trailers don't contain %q: %q", want, trailers)
+		}
+	})
+}
+
+func TestHTTP_GRPCWebTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	pool := genCert(t, "localhost", certFile, keyFile)
+	tlsCfg := &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS13}
+
+	port, conn := setupWithCreds(t, credentials.NewTLS(tlsCfg),
+		"--grpc-web.enable", "--ssl.enable", "--ssl.cert", certFile, "--ssl.key", keyFile)
+	defer conn.Close()
+	waitForServerUp(t, conn)
+
+	resp, err := echopb.NewEchoServiceClient(conn).Echo(context.Background(), &echopb.EchoRequest{Ping: "hello"})
+	assert(t, err == nil, "unexpected error: %v", err)
+	assert(t, resp.Tls.GetAlpn() == "h2", "unexpected tls info: %v", resp.Tls)
+
+	msg, err := proto.Marshal(&echopb.EchoRequest{Ping: "hello"})
+	assert(t, err == nil, "failed to marshal request: %v", err)
+
+	req, err := http.NewRequest(http.MethodPost,
+		fmt.Sprintf("https://localhost:%d/grpc_echo.v1.EchoService/Echo", port),
+		bytes.NewReader(makeWebFrame(0, msg)))
+	assert(t, err == nil, "failed to make request: %v", err)
+	req.Header.Set("Content-Type", "application/grpc-web+proto")
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsCfg, ForceAttemptHTTP2: true}}
+	httpResp, err := client.Do(req)
+	assert(t, err == nil, "failed to do request: %v", err)
+	defer httpResp.Body.Close()
+	assert(t, httpResp.ProtoMajor == 2, "unexpected protocol: %s", httpResp.Proto)
+
+	body, err := io.ReadAll(httpResp.Body)
+	assert(t, err == nil, "failed to read response: %v", err)
+
+	frames := parseWebFrames(t, body)
+	assert(t, len(frames) == 2, "unexpected number of frames: %d", len(frames))
+
+	echoResp := &echopb.EchoResponse{}
+	err = proto.Unmarshal(frames[0].payload, echoResp)
+	assert(t, err == nil, "failed to unmarshal response: %v", err)
+	assert(t, echoResp.Body == "hello", "unexpected response body: %+v", echoResp.Body)
+	assert(t, echoResp.Tls.GetAlpn() == "h2", "unexpected tls info: %v", echoResp.Tls)
+}
+
+func TestHTTP_Connect(t *testing.T) {
+	port, conn := setup(t, "--connect")
+	defer conn.Close()
+	waitForServerUp(t, conn)
+
+	baseURL := fmt.Sprintf("http://localhost:%d", port)
+
+	t.Run("json", func(t *testing.T) {
+		resp, err := http.Post(baseURL+"/grpc_echo.v1.EchoService/Echo", "application/json",
+			strings.NewReader(`{"ping": "hello", "responseHeaders": {"x-resp": "val"}}`))
+		assert(t, err == nil, "failed to do request: %v", err)
+		defer resp.Body.Close()
+
+		assert(t, resp.StatusCode == http.StatusOK, "unexpected status: %d", resp.StatusCode)
+		assert(t, resp.Header.Get("X-Resp") == "val", "unexpected response headers: %v", resp.Header)
+
+		body, err := io.ReadAll(resp.Body)
+		assert(t, err == nil, "failed to read response: %v", err)
+
+		echoResp := &echopb.EchoResponse{}
+		err = protojson.Unmarshal(body, echoResp)
+		assert(t, err == nil, "failed to unmarshal response %s: %v", body, err)
+		assert(t, echoResp.Body == "hello", "unexpected response body: %+v", echoResp.Body)
+		assert(t, echoResp.Headers["content-type"] == "application/json", "unexpected headers: %v", echoResp.Headers)
+		assert(t, echoResp.RemoteAddr != "", "remote address must be set")
+		assert(t, echoResp.ReceivedAt != nil && echoResp.SentAt != nil, "interceptor timestamps must be set")
+	})
+
+	client := echopbconnect.NewEchoServiceClient(http.DefaultClient, baseURL)
+
+	t.Run("proto", func(t *testing.T) {
+		req := connect.NewRequest(&echopb.EchoRequest{Ping: "hello"})
+		req.Header().Set("X-Custom-Bin", connect.EncodeBinaryHeader([]byte{0xff, 0x00}))
+
+		resp, err := client.Echo(context.Background(), req)
+		assert(t, err == nil, "unexpected error: %v", err)
+		assert(t, resp.Msg.Body == "hello", "unexpected response body: %+v", resp.Msg.Body)
+
+		vals := resp.Msg.Metadata["x-custom-bin"].GetBinaryValues()
+		assert(t, len(vals) == 1 && bytes.Equal(vals[0], []byte{0xff, 0x00}), "unexpected binary metadata: %v", vals)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := client.Echo(context.Background(), connect.NewRequest(&echopb.EchoRequest{
+			Status: &echopb.Status{Code: uint32(codes.NotFound), Message: "not here"},
+		}))
+		assert(t, connect.CodeOf(err) == connect.CodeNotFound, "unexpected error: %v", err)
+
+		var cerr *connect.Error
+		assert(t, errors.As(err, &cerr) && cerr.Message() == "not here", "unexpected error: %v", err)
+	})
+
+	t.Run("streaming is not supported", func(t *testing.T) {
+		stream, err := client.EchoStream(context.Background(), connect.NewRequest(&echopb.EchoStreamRequest{Count: 1}))
+		assert(t, err == nil, "unexpected error: %v", err)
+		defer stream.Close()
+
+		assert(t, !stream.Receive(), "unexpected message")
+		assert(t, connect.CodeOf(stream.Err()) == connect.CodeUnimplemented, "unexpected error: %v", stream.Err())
+	})
+}
+
+func TestHTTP_REST(t *testing.T) {
+	port, conn := setup(t, "--rest")
+	defer conn.Close()
+	waitForServerUp(t, conn)
+
+	url := fmt.Sprintf("http://localhost:%d/v1/echo", port)
+
+	t.Run("echo", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, url,
+			strings.NewReader(`{"ping": "hello", "responseHeaders": {"x-resp": "val"}, "responseTrailers": {"x-trail": "val"}}`))
+		assert(t, err == nil, "failed to make request: %v", err)
+		req.Header.Set("X-Custom", "custom")
+		req.Header.Set("Grpc-Timeout", "3000m")
+
+		resp, err := http.DefaultClient.Do(req)
+		assert(t, err == nil, "failed to do request: %v", err)
+		defer resp.Body.Close()
+
+		assert(t, resp.StatusCode == http.StatusOK, "unexpected status: %d", resp.StatusCode)
+		assert(t, resp.Header.Get("Content-Type") == "application/json", "unexpected content type: %v", resp.Header)
+		assert(t, resp.Header.Get("X-Resp") == "val", "unexpected response headers: %v", resp.Header)
+
+		body, err := io.ReadAll(resp.Body)
+		assert(t, err == nil, "failed to read response: %v", err)
+		assert(t, resp.Trailer.Get("X-Trail") == "val", "unexpected response trailers: %v", resp.Trailer)
+
+		echoResp := &echopb.EchoResponse{}
+		err = protojson.Unmarshal(body, echoResp)
+		assert(t, err == nil, "failed to unmarshal response %s: %v", body, err)
+		assert(t, echoResp.Body == "hello", "unexpected response body: %+v", echoResp.Body)
+		assert(t, echoResp.Headers["x-custom"] == "custom", "unexpected headers: %v", echoResp.Headers)
+		assert(t, echoResp.Headers[":authority"] == fmt.Sprintf("localhost:%d", port), "unexpected headers: %v", echoResp.Headers)
+		assert(t, echoResp.RemoteAddr != "", "remote address must be set")
+		assert(t, echoResp.ReceivedAt != nil && echoResp.SentAt != nil, "interceptor timestamps must be set")
+		assert(t, echoResp.GrpcTimeout == "3000m", "grpc-timeout must be echoed as is: %q", echoResp.GrpcTimeout)
+		_, ok := echoResp.Headers["grpc-timeout"]
+		assert(t, !ok, "grpc-timeout must not be in headers: %v", echoResp.Headers)
+	})
+
+	tbl := []struct {
+		name   string
+		method string
+		body   string
+		code   int
+	}{
+		{name: "status", method: http.MethodPost, body: `{"status": {"code": 5, "message": "not here"}}`, code: http.StatusNotFound},
+		{name: "invalid json", method: http.MethodPost, body: `{"ping": 1}`, code: http.StatusBadRequest},
+		{name: "too large", method: http.MethodPost, body: `{"ping": "` + strings.Repeat("a", 1024*5) + `"}`, code: http.StatusRequestEntityTooLarge},
+		{name: "wrong method", method: http.MethodGet, code: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tbl {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, url, strings.NewReader(tt.body))
+			assert(t, err == nil, "failed to make request: %v", err)
+
+			resp, err := http.DefaultClient.Do(req)
+			assert(t, err == nil, "failed to do request: %v", err)
+			defer resp.Body.Close()
+			assert(t, resp.StatusCode == tt.code, "unexpected status: %d", resp.StatusCode)
+		})
+	}
+}
+
+func TestHTTP_HealthAndInfo(t *testing.T) {
+	port, conn := setup(t, "--http")
+	defer conn.Close()
+	waitForServerUp(t, conn) // native gRPC over h2c must keep working on the same port
+
+	baseURL := fmt.Sprintf("http://localhost:%d", port)
+
+	h2cClient := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}}
+
+	for name, client := range map[string]*http.Client{"http1": http.DefaultClient, "h2c": h2cClient} {
+		t.Run(name, func(t *testing.T) {
+			resp, err := client.Get(baseURL + "/health")
+			assert(t, err == nil, "failed to do request: %v", err)
+			body, err := io.ReadAll(resp.Body)
+			assert(t, err == nil, "failed to read response: %v", err)
+			_ = resp.Body.Close()
+			assert(t, resp.StatusCode == http.StatusOK, "unexpected status: %d", resp.StatusCode)
+			assert(t, strings.TrimSpace(string(body)) == "SERVING", "unexpected body: %s", body)
+			if name == "h2c" {
+				assert(t, resp.ProtoMajor == 2, "unexpected protocol: %s", resp.Proto)
+			}
+
+			resp, err = client.Get(baseURL + "/health?service=unknown")
+			assert(t, err == nil, "failed to do request: %v", err)
+			_ = resp.Body.Close()
+			assert(t, resp.StatusCode == http.StatusNotFound, "unexpected status: %d", resp.StatusCode)
+
+			resp, err = client.Get(baseURL + "/")
+			assert(t, err == nil, "failed to do request: %v", err)
+			body, err = io.ReadAll(resp.Body)
+			assert(t, err == nil, "failed to read response: %v", err)
+			_ = resp.Body.Close()
+			assert(t, resp.StatusCode == http.StatusOK, "unexpected status: %d", resp.StatusCode)
+			assert(t, strings.Contains(string(body), "grpc_echo.v1.EchoService: Collect, Echo, EchoBidi, EchoStream"),
+				"unexpected info page: %s", body)
+
+			resp, err = client.Get(baseURL + "/unknown")
+			assert(t, err == nil, "failed to do request: %v", err)
+			_ = resp.Body.Close()
+			assert(t, resp.StatusCode == http.StatusNotFound, "unexpected status: %d", resp.StatusCode)
+		})
+	}
+}
+
+func TestHTTP_Metrics(t *testing.T) {
+	adminPort := 30000 + rand.IntN(10000)
+	port, conn := setup(t, "--metrics", "--admin.addr", fmt.Sprintf("localhost:%d", adminPort))
+	defer conn.Close()
+	waitForServerUp(t, conn)
+
+	client := echopb.NewEchoServiceClient(conn)
+	_, err := client.Echo(context.Background(), &echopb.EchoRequest{Ping: "hello"})
+	assert(t, err == nil, "unexpected error: %v", err)
+	_, err = client.Echo(context.Background(), &echopb.EchoRequest{Status: &echopb.Status{Code: uint32(codes.NotFound)}})
+	assert(t, status.Code(err) == codes.NotFound, "unexpected error: %v", err)
+
+	stream, err := client.EchoBidi(context.Background())
+	assert(t, err == nil, "unexpected error: %v", err)
+	defer stream.CloseSend()
+	err = stream.Send(&echopb.EchoRequest{Ping: "hello"})
+	assert(t, err == nil, "unexpected error: %v", err)
+	_, err = stream.Recv()
+	assert(t, err == nil, "unexpected error: %v", err)
+
+	// metrics must not switch the gRPC listener to HTTP server
+	_, err = http.Get(fmt.Sprintf("http://localhost:%d/metrics", port))
+	assert(t, err != nil, "gRPC listener must not serve HTTP/1.1")
+
+	resp, err := http.Get(fmt.Sprintf("http://localhost:%d/metrics", adminPort))
+	assert(t, err == nil, "failed to do request: %v", err)
+	defer resp.Body.Close()
+	assert(t, resp.StatusCode == http.StatusOK, "unexpected status: %d", resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	assert(t, err == nil, "failed to read response: %v", err)
+
+	for _, want := range []string{
+		`grpc_server_started_total{method="/grpc_echo.v1.EchoService/Echo",type="unary"} 2`,
+		`grpc_server_handled_total{code="OK",method="/grpc_echo.v1.EchoService/Echo",type="unary"} 1`,
+		`grpc_server_handled_total{code="NotFound",method="/grpc_echo.v1.EchoService/Echo",type="unary"} 1`,
+		`grpc_server_handling_seconds_count{method="/grpc_echo.v1.EchoService/Echo",type="unary"} 2`,
+		`grpc_server_streams_in_flight{method="/grpc_echo.v1.EchoService/EchoBidi",type="bidi_stream"} 1`,
+		fmt.Sprintf(`grpc_server_open_connections{listener="tcp://:%d?tls=false"} 1`, port),
+		"go_goroutines",
+	} {
+		assert(t, strings.Contains(string(body), want), "metrics don't contain %q:\n%s", want, body)
+	}
+}
+
+func TestHTTP_Admin(t *testing.T) {
+	adminPort := 30000 + rand.IntN(10000)
+	_, conn := setup(t, "--admin.addr", fmt.Sprintf("localhost:%d", adminPort))
+	defer conn.Close()
+	waitForServerUp(t, conn)
+
+	_, err := echopb.NewEchoServiceClient(conn).Echo(context.Background(), &echopb.EchoRequest{Ping: "hello"})
+	assert(t, err == nil, "unexpected error: %v", err)
+
+	get := func(t *testing.T, path string) []byte {
+		resp, err := http.Get(fmt.Sprintf("http://localhost:%d%s", adminPort, path))
+		assert(t, err == nil, "failed to do request: %v", err)
+		defer resp.Body.Close()
+		assert(t, resp.StatusCode == http.StatusOK, "unexpected status: %d", resp.StatusCode)
+
+		body, err := io.ReadAll(resp.Body)
+		assert(t, err == nil, "failed to read response: %v", err)
+		return body
+	}
+
+	t.Run("version", func(t *testing.T) {
+		var info versionInfo
+		err := json.Unmarshal(get(t, "/version"), &info)
+		assert(t, err == nil, "failed to unmarshal version: %v", err)
+		assert(t, info.Version == getVersion(), "unexpected version: %q", info.Version)
+		assert(t, info.GoVersion == runtime.Version(), "unexpected go version: %q", info.GoVersion)
+	})
+
+	t.Run("config", func(t *testing.T) {
+		var cfg struct{ Admin struct{ Addr string } }
+		err := json.Unmarshal(get(t, "/config"), &cfg)
+		assert(t, err == nil, "failed to unmarshal config: %v", err)
+		assert(t, cfg.Admin.Addr == fmt.Sprintf("localhost:%d", adminPort), "unexpected config: %+v", cfg)
+	})
+
+	t.Run("pprof", func(t *testing.T) {
+		body := get(t, "/debug/pprof/goroutine?debug=1")
+		assert(t, bytes.Contains(body, []byte("goroutine profile")), "unexpected profile: %s", body)
+	})
+
+	t.Run("channelz", func(t *testing.T) {
+		adminConn, err := grpc.NewClient(fmt.Sprintf("localhost:%d", adminPort),
+			grpc.WithTransportCredentials(insecure.NewCredentials()))
+		assert(t, err == nil, "failed to dial admin server: %v", err)
+		defer adminConn.Close()
+
+		resp, err := channelzpb.NewChannelzClient(adminConn).GetServers(context.Background(), &channelzpb.GetServersRequest{})
+		assert(t, err == nil, "unexpected error: %v", err)
+
+		// echo server is the only one served over gRPC transport, thus with listen sockets
+		var calls int64
+		for _, srv := range resp.Server {
+			if len(srv.ListenSocket) > 0 {
+				calls += srv.Data.CallsSucceeded
+			}
+		}
+		assert(t, calls >= 2, "unexpected number of succeeded calls: %d", calls)
+	})
+}
+
+func TestHTTP_NativeGRPC(t *testing.T) {
+	adminPort := 30000 + rand.IntN(10000)
+	port, conn := setup(t, "--rest", "--admin.addr", fmt.Sprintf("localhost:%d", adminPort))
+	defer conn.Close()
+	waitForServerUp(t, conn)
+
+	_, err := echopb.NewEchoServiceClient(conn).Echo(context.Background(), &echopb.EchoRequest{Ping: "hello"})
+	assert(t, err == nil, "unexpected error: %v", err)
+
+	t.Run("served by gRPC transport", func(t *testing.T) {
+		adminConn, err := grpc.NewClient(fmt.Sprintf("localhost:%d", adminPort),
+			grpc.WithTransportCredentials(insecure.NewCredentials()))
+		assert(t, err == nil, "failed to dial admin server: %v", err)
+		defer adminConn.Close()
+
+		resp, err := channelzpb.NewChannelzClient(adminConn).GetServers(context.Background(), &channelzpb.GetServersRequest{})
+		assert(t, err == nil, "unexpected error: %v", err)
+
+		var calls int64
+		for _, srv := range resp.Server {
+			if len(srv.ListenSocket) > 0 {
+				calls += srv.Data.CallsSucceeded
+			}
+		}
+		assert(t, calls >= 2, "unexpected number of succeeded calls: %d", calls)
+	})
+
+	t.Run("h2c with prior knowledge", func(t *testing.T) {
+		var dials atomic.Int32
+		client := &http.Client{Transport: &http2.Transport{
+			AllowHTTP: true,
+			DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+				dials.Add(1)
+				return (&net.Dialer{}).DialContext(ctx, network, addr)
+			},
+		}}
+
+		// the connection must survive the first request, as the client
+		// acknowledges the settings sent by the demultiplexer after it
+		for range 2 {
+			resp, err := client.Post(fmt.Sprintf("http://localhost:%d/v1/echo", port), "application/json",
+				strings.NewReader(`{"ping": "hello"}`))
+			assert(t, err == nil, "failed to do request: %v", err)
+			assert(t, resp.ProtoMajor == 2, "unexpected protocol: %s", resp.Proto)
+			assert(t, resp.StatusCode == http.StatusOK, "unexpected status: %d", resp.StatusCode)
+			_ = resp.Body.Close()
+		}
+		assert(t, dials.Load() == 1, "unexpected number of connections: %d", dials.Load())
+	})
+}
+
+type webFrame struct {
+	flags   byte
+	payload []byte
+}
+
+func makeWebFrame(flags byte, payload []byte) []byte {
+	frame := make([]byte, 5, 5+len(payload))
+	frame[0] = flags
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(payload)))
+	return append(frame, payload...)
+}
+
+func parseWebFrames(tb testing.TB, b []byte) (frames []webFrame) {
+	tb.Helper()
+	for len(b) > 0 {
+		assert(tb, len(b) >= 5, "truncated frame header: %v", b)
+		n := int(binary.BigEndian.Uint32(b[1:5]))
+		assert(tb, len(b) >= 5+n, "truncated frame payload: %v", b)
+		frames = append(frames, webFrame{flags: b[0], payload: b[5 : 5+n]})
+		b = b[5+n:]
+	}
+	return frames
+}
+
+// decodeWebText decodes the gRPC-Web text response, which may consist
+// of several concatenated base64-encoded chunks, each with its own padding.
+func decodeWebText(tb testing.TB, s string) (res []byte) {
+	tb.Helper()
+	for s != "" {
+		end := strings.IndexByte(s, '=')
+		if end < 0 {
+			end = len(s)
+		}
+		for end < len(s) && s[end] == '=' {
+			end++
+		}
+
+		chunk, err := base64.StdEncoding.DecodeString(s[:end])
+		assert(tb, err == nil, "failed to decode chunk %q: %v", s[:end], err)
+		res, s = append(res, chunk...), s[end:]
+	}
+	return res
+}
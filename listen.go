@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pires/go-proxyproto"
+)
+
+// listenAddr describes an address to listen on.
+type listenAddr struct {
+	Network string // tcp or unix
+	Address string
+	TLS     bool
+}
+
+// String returns the address in the form it's accepted by parseListenAddr.
+func (a listenAddr) String() string {
+	return fmt.Sprintf("%s://%s?tls=%t", a.Network, a.Address, a.TLS)
+}
+
+// parseListenAddr parses the address in the form of [scheme://]address[?tls=bool],
+// where scheme is either tcp (default) or unix. Unix socket address is either
+// a path or a name starting with "@" for an abstract socket. If tls parameter
+// is not set, defaultTLS is used.
+func parseListenAddr(s string, defaultTLS bool) (listenAddr, error) {
+	res := listenAddr{Network: "tcp", Address: s, TLS: defaultTLS}
+
+	if scheme, addr, ok := strings.Cut(s, "://"); ok {
+		switch scheme {
+		case "tcp", "unix":
+			res.Network, res.Address = scheme, addr
+		default:
+			return listenAddr{}, fmt.Errorf("unsupported scheme %q", scheme)
+		}
+	}
+
+	if addr, query, ok := strings.Cut(res.Address, "?"); ok {
+		res.Address = addr
+		key, val, _ := strings.Cut(query, "=")
+		if key != "tls" {
+			return listenAddr{}, fmt.Errorf("unsupported parameter %q", key)
+		}
+
+		var err error
+		if res.TLS, err = strconv.ParseBool(val); err != nil {
+			return listenAddr{}, fmt.Errorf("parse tls parameter: %w", err)
+		}
+	}
+
+	if res.Address == "" {
+		return listenAddr{}, errors.New("empty address")
+	}
+
+	return res, nil
+}
+
+// listen listens on the address, removing the stale unix socket file,
+// if it's left from the previous run.
+func listen(addr listenAddr) (net.Listener, error) {
+	if addr.Network == "unix" && !strings.HasPrefix(addr.Address, "@") {
+		if fi, err := os.Stat(addr.Address); err == nil && fi.Mode().Type() == fs.ModeSocket {
+			if err = os.Remove(addr.Address); err != nil {
+				return nil, fmt.Errorf("remove stale socket: %w", err)
+			}
+		}
+	}
+
+	return net.Listen(addr.Network, addr.Address)
+}
+
+// proxyProtoListener wraps the listener to read PROXY protocol v1 and v2
+// headers of connections from trusted sources, so that the connection's
+// remote address is the one of the original client. In "required" mode
+// connections from trusted sources without the header are rejected,
+// as well as connections from untrusted sources, while in "optional" mode
+// headers from untrusted sources are ignored. If trusted is empty, any
+// source is trusted, otherwise only TCP ones are.
+func proxyProtoListener(lis net.Listener, mode string, trusted []*net.IPNet) net.Listener {
+	return &proxyproto.Listener{
+		Listener: lis,
+		Policy: func(upstream net.Addr) (proxyproto.Policy, error) {
+			isTrusted := len(trusted) == 0
+			if addr, ok := upstream.(*net.TCPAddr); ok && !isTrusted {
+				isTrusted = slices.ContainsFunc(trusted, func(n *net.IPNet) bool { return n.Contains(addr.IP) })
+			}
+
+			switch {
+			case isTrusted && mode == "required":
+				return proxyproto.REQUIRE, nil
+			case isTrusted:
+				return proxyproto.USE, nil
+			case mode == "required":
+				return proxyproto.REJECT, proxyproto.ErrInvalidUpstream
+			default:
+				return proxyproto.IGNORE, nil
+			}
+		},
+	}
+}
+
+// parseCIDRs parses the list of CIDRs, single IPs are accepted as well.
+func parseCIDRs(ss []string) ([]*net.IPNet, error) {
+	res := make([]*net.IPNet, 0, len(ss))
+	for _, s := range ss {
+		if ip := net.ParseIP(s); ip != nil {
+			bits := 8 * net.IPv6len
+			if v4 := ip.To4(); v4 != nil {
+				ip, bits = v4, 8*net.IPv4len
+			}
+			res = append(res, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, fmt.Errorf("parse cidr %q: %w", s, err)
+		}
+		res = append(res, ipNet)
+	}
+	return res, nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestParseListenAddr(t *testing.T) {
+	tbl := []struct {
+		in         string
+		defaultTLS bool
+		want       listenAddr
+		wantErr    bool
+	}{
+		{in: ":8080", want: listenAddr{Network: "tcp", Address: ":8080"}},
+		{in: ":8080", defaultTLS: true, want: listenAddr{Network: "tcp", Address: ":8080", TLS: true}},
+		{in: "tcp://127.0.0.1:8443?tls=true", want: listenAddr{Network: "tcp", Address: "127.0.0.1:8443", TLS: true}},
+		{in: "unix:///run/echo.sock", defaultTLS: true, want: listenAddr{Network: "unix", Address: "/run/echo.sock", TLS: true}},
+		{in: "unix://@echo?tls=false", defaultTLS: true, want: listenAddr{Network: "unix", Address: "@echo"}},
+		{in: "udp://:8080", wantErr: true},
+		{in: ":8080?mtls=true", wantErr: true},
+		{in: ":8080?tls=maybe", wantErr: true},
+		{in: "unix://", wantErr: true},
+	}
+
+	for _, tt := range tbl {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseListenAddr(tt.in, tt.defaultTLS)
+			assert(t, (err != nil) == tt.wantErr, "unexpected error: %v", err)
+			assert(t, got == tt.want, "unexpected result: %+v", got)
+		})
+	}
+}
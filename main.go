@@ -25,13 +25,34 @@ import (
 	"google.golang.org/grpc/grpclog"
 	"time"
 	"google.golang.org/grpc/keepalive"
+	"crypto/tls"
+	"crypto/x509"
+	"github.com/Semior001/grpc-echo/pkg/tlsx"
+	"net/http"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
 var opts struct {
 	SSL struct {
-		Enable bool   `long:"enable"        env:"ENABLE" description:"Enable SSL"`
-		Cert   string `long:"cert"          env:"CERT"   description:"path to cert.pem file"`
-		Key    string `long:"key"           env:"KEY"    description:"path to key.pem file"`
+		Enable     bool   `long:"enable"        env:"ENABLE"      description:"Enable SSL"`
+		Type       string `long:"type"          env:"TYPE"        choice:"static" choice:"self-signed" default:"static" description:"ssl type, self-signed generates an in-memory CA and certificate at startup"`
+		Cert       string `long:"cert"          env:"CERT"        description:"path to cert.pem file"`
+		Key        string `long:"key"           env:"KEY"         description:"path to key.pem file"`
+		ClientCA   string `long:"client-ca"     env:"CLIENT_CA"   description:"path to client CA bundle file"`
+		ClientAuth string `long:"client-auth"   env:"CLIENT_AUTH" choice:"none" choice:"request" choice:"require" choice:"verify" default:"none" description:"client certificate policy"`
+
+		Hosts []string `long:"host"   env:"HOSTS"  env-delim:"," default:"localhost" default:"127.0.0.1" default:"::1" description:"hostnames and IPs for the self-signed certificate"`
+		CAOut string   `long:"ca-out" env:"CA_OUT"                 description:"path to write the self-signed CA certificate to"`
+
+		ReloadInterval time.Duration `long:"reload-interval" env:"RELOAD_INTERVAL" description:"interval to check cert and key files for changes, 0 means no checks, SIGHUP reloads them anyway"`
 	} `group:"ssl" namespace:"ssl" env-namespace:"SSL" description:"ssl settings"`
 
 	Keepalive struct {
@@ -40,11 +61,55 @@ var opts struct {
 		Time        time.Duration `long:"time"          env:"TIME"               default:"1s"   description:"interval between server pings"`
 	} `group:"keepalive" namespace:"keepalive" env-namespace:"KEEPALIVE" description:"keepalive settings"`
 
+	GRPCWeb struct {
+		Enable         bool     `long:"enable"         env:"ENABLE"                                    description:"enable gRPC-Web on the same listener"`
+		AllowedOrigins []string `long:"allowed-origin" env:"ALLOWED_ORIGINS" env-delim:"," default:"*" description:"origins allowed to make CORS requests, * allows any"`
+		AllowedHeaders []string `long:"allowed-header" env:"ALLOWED_HEADERS" env-delim:"," default:"*" description:"headers allowed in CORS requests, * allows any"`
+	} `group:"grpc-web" namespace:"grpc-web" env-namespace:"GRPC_WEB" description:"gRPC-Web settings"`
+
+	ProxyProtocol struct {
+		Mode        string   `long:"mode"         env:"MODE"                       choice:"off" choice:"optional" choice:"required" default:"off" description:"PROXY protocol v1/v2 mode of the listeners"`
+		TrustedCIDR []string `long:"trusted-cidr" env:"TRUSTED_CIDR" env-delim:","                                                                description:"CIDRs of proxies allowed to send PROXY header, any if empty"`
+	} `group:"proxy-protocol" namespace:"proxy-protocol" env-namespace:"PROXY_PROTOCOL" description:"PROXY protocol settings"`
+
+	RealIP struct {
+		TrustedProxies []string `long:"trusted-proxy" env:"TRUSTED_PROXIES" env-delim:","                                              description:"CIDRs of proxies allowed to set client address headers"`
+		Headers        []string `long:"header"        env:"HEADERS"         env-delim:"," default:"X-Forwarded-For" default:"X-Real-Ip" description:"headers with client address, in order of precedence, Forwarded is supported"`
+	} `group:"real-ip" namespace:"real-ip" env-namespace:"REAL_IP" description:"client address resolution settings"`
+
+	OTel struct {
+		Endpoint    string `long:"endpoint"     env:"ENDPOINT"                         description:"OTLP gRPC endpoint to export traces to, host:port, traces are not exported if empty"`
+		Insecure    bool   `long:"insecure"     env:"INSECURE"                         description:"disable TLS for the OTLP endpoint"`
+		ServiceName string `long:"service-name" env:"SERVICE_NAME" default:"grpc-echo" description:"service name of the exported traces"`
+	} `group:"otel" namespace:"otel" env-namespace:"OTEL" description:"OpenTelemetry tracing settings"`
+
+	AccessLog struct {
+		Enable     bool     `long:"enable"      env:"ENABLE"                                                                                              description:"enable access log"`
+		Fields     []string `long:"field"       env:"FIELDS"      env-delim:"," choice:"code" choice:"request_size" choice:"response_size" choice:"user_agent" choice:"authority" choice:"real_ip" choice:"metadata" choice:"trace_id" default:"code" default:"real_ip" default:"user_agent" default:"trace_id" description:"fields to log besides method and duration"`
+		Metadata   []string `long:"metadata"    env:"METADATA"    env-delim:","                                                                           description:"metadata keys to log with metadata field"`
+		SampleRate float64  `long:"sample-rate" env:"SAMPLE_RATE" default:"1"                                                                             description:"fraction of successful calls to log, failed ones are logged anyway"`
+		File       string   `long:"file"        env:"FILE"                                                                                                description:"file to write access log to, instead of the main log"`
+	} `group:"access-log" namespace:"access-log" env-namespace:"ACCESS_LOG" description:"access log settings"`
+
+	GRPCLog struct {
+		Severity  string `long:"severity"  env:"SEVERITY"  choice:"info" choice:"warning" choice:"error" default:"error" description:"min severity of gRPC internal logs"`
+		Verbosity int    `long:"verbosity" env:"VERBOSITY"                                                               description:"verbosity level of gRPC internal logs"`
+	} `group:"grpc-log" namespace:"grpc-log" env-namespace:"GRPC_LOG" description:"gRPC internal logging settings"`
+
+	Admin struct {
+		Addr string `long:"addr" env:"ADDR" description:"address of the admin server with pprof, channelz, version and config, [tcp://|unix://]addr[?tls=bool], disabled if empty"`
+	} `group:"admin" namespace:"admin" env-namespace:"ADMIN" description:"admin server settings"`
+
+	HTTP    bool `long:"http"    env:"HTTP"    description:"serve HTTP/1.1 and h2c requests on the same listener, with health check and info page"`
+	Connect bool `long:"connect" env:"CONNECT" description:"enable Connect protocol on the same listener"`
+	REST    bool `long:"rest"    env:"REST"    description:"enable HTTP/JSON endpoint POST /v1/echo on the same listener"`
+	Metrics bool `long:"metrics" env:"METRICS" description:"enable Prometheus metrics at /metrics on the admin listener, requires admin.addr"`
+
 	StreamTimeout time.Duration `long:"stream-timeout" env:"STREAM_TIMEOUT" default:"5s" description:"stream timeout, 0 means no timeout"`
 
-	Addr  string `short:"a" long:"addr" env:"ADDR" default:":8080" description:"Address to listen on"`
-	JSON  bool   `long:"json"           env:"JSON"                 description:"Enable JSON logging"`
-	Debug bool   `long:"debug"          env:"DEBUG"                description:"Enable debug mode"`
+	Addr  []string `short:"a" long:"addr" env:"ADDR" env-delim:"," default:":8080" description:"Addresses to listen on, [tcp://|unix://]addr[?tls=bool], tls follows ssl.enable by default"`
+	JSON  bool     `long:"json"           env:"JSON"                                description:"Enable JSON logging"`
+	Debug bool     `long:"debug"          env:"DEBUG"                               description:"Enable debug mode"`
 }
 
 var version = "unknown"
@@ -63,7 +128,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	setupLog(opts.Debug, opts.JSON)
+	setupLog(opts.Debug, opts.JSON, opts.GRPCLog.Severity, opts.GRPCLog.Verbosity)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	go func() { // catch signal and invoke graceful termination
@@ -80,40 +145,173 @@ func main() {
 }
 
 func run(ctx context.Context) error {
-	svc := &service.EchoService{}
+	trustedProxies, err := parseCIDRs(opts.RealIP.TrustedProxies)
+	if err != nil {
+		return fmt.Errorf("parse real ip trusted proxies: %w", err)
+	}
+
+	svc := &service.EchoService{RealIP: grpcx.RealIPResolver{
+		TrustedProxies: trustedProxies,
+		Headers:        opts.RealIP.Headers,
+	}}
 	healthHandler := health.NewServer()
 
-	var cred credentials.TransportCredentials
+	tp, err := makeTracerProvider(ctx)
+	if err != nil {
+		return fmt.Errorf("make tracer provider: %w", err)
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := tp.Shutdown(shutdownCtx); err != nil {
+			slog.Warn("failed to flush traces", slog.Any("error", err))
+		}
+	}()
+
+	tracing := grpcx.Tracing{
+		Tracer: tp.Tracer("github.com/Semior001/grpc-echo"),
+		Propagator: propagation.NewCompositeTextMapPropagator(
+			propagation.TraceContext{},
+			propagation.Baggage{},
+			b3.New(),
+		),
+	}
+
+	var (
+		cred     credentials.TransportCredentials
+		tlsCfg   *tls.Config
+		reloader *tlsx.CertReloader
+	)
+
+	addrs := make([]listenAddr, 0, len(opts.Addr))
+	for _, s := range opts.Addr {
+		var addr listenAddr
+		if addr, err = parseListenAddr(s, opts.SSL.Enable); err != nil {
+			return fmt.Errorf("parse listen address %q: %w", s, err)
+		}
+		if addr.TLS && !opts.SSL.Enable {
+			return fmt.Errorf("listen address %q requires ssl to be enabled", s)
+		}
+		addrs = append(addrs, addr)
+	}
 
-	lis, err := net.Listen("tcp", opts.Addr)
+	if opts.Metrics && opts.Admin.Addr == "" {
+		return fmt.Errorf("metrics require admin address to be set")
+	}
+
+	var adminAddr listenAddr
+	if opts.Admin.Addr != "" {
+		if adminAddr, err = parseListenAddr(opts.Admin.Addr, false); err != nil {
+			return fmt.Errorf("parse admin address %q: %w", opts.Admin.Addr, err)
+		}
+		if adminAddr.TLS && !opts.SSL.Enable {
+			return fmt.Errorf("admin address %q requires ssl to be enabled", opts.Admin.Addr)
+		}
+	}
+
+	proxyProtoTrusted, err := parseCIDRs(opts.ProxyProtocol.TrustedCIDR)
 	if err != nil {
-		return fmt.Errorf("listen on %s: %w", opts.Addr, err)
+		return fmt.Errorf("parse proxy protocol trusted cidrs: %w", err)
+	}
+
+	var metrics *grpcx.Metrics
+	metricsReg := prometheus.NewRegistry()
+	if opts.Metrics {
+		metricsReg.MustRegister(
+			collectors.NewGoCollector(),
+			collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		)
+		metrics = grpcx.NewMetrics(metricsReg)
+	}
+
+	var (
+		listeners = make([]net.Listener, 0, len(addrs))
+		adminLis  net.Listener
+		served    bool
+	)
+	// listeners are closed by the servers once they are started,
+	// until then they must be closed on any failure
+	defer func() {
+		if served {
+			return
+		}
+		for _, lis := range listeners {
+			_ = lis.Close()
+		}
+		if adminLis != nil {
+			_ = adminLis.Close()
+		}
+	}()
+
+	for _, addr := range addrs {
+		var lis net.Listener
+		if lis, err = listen(addr); err != nil {
+			return fmt.Errorf("listen on %s: %w", addr, err)
+		}
+		if opts.ProxyProtocol.Mode != "off" {
+			lis = proxyProtoListener(lis, opts.ProxyProtocol.Mode, proxyProtoTrusted)
+		}
+		if metrics != nil {
+			lis = metrics.Listener(lis, addr.String())
+		}
+		if !addr.TLS {
+			lis = grpcx.PlaintextListener(lis)
+		}
+		listeners = append(listeners, lis)
+	}
+
+	if opts.Admin.Addr != "" {
+		if adminLis, err = listen(adminAddr); err != nil {
+			return fmt.Errorf("listen admin on %s: %w", adminAddr, err)
+		}
 	}
 
 	if opts.SSL.Enable {
-		if opts.SSL.Cert == "" || opts.SSL.Key == "" {
-			return fmt.Errorf("cert and key must be provided for static ssl")
+		var getCert func(*tls.ClientHelloInfo) (*tls.Certificate, error)
+		if getCert, reloader, err = makeCertificate(); err != nil {
+			return fmt.Errorf("make certificate: %w", err)
+		}
+
+		if tlsCfg, err = makeTLSConfig(getCert); err != nil {
+			return fmt.Errorf("make tls config: %w", err)
 		}
 
-		slog.Info("using static ssl",
-			slog.String("cert", opts.SSL.Cert),
-			slog.String("key", opts.SSL.Key))
+		// listeners may have TLS disabled
+		cred = grpcx.PerListenerCredentials(credentials.NewTLS(tlsCfg))
+	}
 
-		if cred, err = credentials.NewServerTLSFromFile(opts.SSL.Cert, opts.SSL.Key); err != nil {
-			return fmt.Errorf("load cert and key: %w", err)
+	unaryInterceptors := []grpc.UnaryServerInterceptor{tracing.UnaryInterceptor}
+	streamInterceptors := []grpc.StreamServerInterceptor{tracing.StreamInterceptor}
+	if metrics != nil {
+		unaryInterceptors = append(unaryInterceptors, metrics.UnaryInterceptor)
+		streamInterceptors = append(streamInterceptors, metrics.StreamInterceptor)
+	}
+	if opts.AccessLog.Enable {
+		var (
+			accessLog grpcx.AccessLog
+			closeLog  func()
+		)
+		if accessLog, closeLog, err = makeAccessLog(svc.RealIP); err != nil {
+			return fmt.Errorf("make access log: %w", err)
 		}
+		defer closeLog()
+		unaryInterceptors = append(unaryInterceptors, accessLog.UnaryInterceptor)
+		streamInterceptors = append(streamInterceptors, accessLog.StreamInterceptor)
 	}
+	unaryInterceptors = append(unaryInterceptors,
+		svc.AppendTimestampInterceptor,
+		grpcx.LogUnaryInterceptor,
+	)
+	streamInterceptors = append(streamInterceptors,
+		grpcx.LogStreamInterceptor,
+		grpcx.TimeoutStreamInterceptor(opts.StreamTimeout),
+	)
 
 	srv := grpc.NewServer(
-		grpc.ChainUnaryInterceptor(
-			svc.AppendTimestampInterceptor,
-			grpcx.LogUnaryInterceptor,
-		),
-		grpc.ChainStreamInterceptor(
-			grpcx.LogStreamInterceptor,
-			grpcx.TimeoutStreamInterceptor(opts.StreamTimeout),
-		),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
 		grpc.Creds(cred),
+		grpc.InTapHandle(grpcx.GRPCTimeoutTap),
 		grpc.ConnectionTimeout(5*time.Second),
 		grpc.MaxConcurrentStreams(1000),
 		grpc.MaxHeaderListSize(1024*4),     // 4KB
@@ -134,19 +332,115 @@ func run(ctx context.Context) error {
 	echopb.RegisterEchoServiceServer(srv, svc)
 	reflection.Register(srv)
 
+	var (
+		httpSrv  *http.Server
+		httpGRPC *httpGRPCServer
+	)
+	if opts.HTTP || opts.GRPCWeb.Enable || opts.Connect || opts.REST {
+		interceptor := grpcx.ChainUnaryInterceptors(unaryInterceptors...)
+		handlers := httpHandlers{
+			Health:  healthHandler,
+			Connect: &service.ConnectHandler{Service: svc, Interceptor: interceptor},
+			REST:    &service.RESTHandler{Service: svc, Interceptor: interceptor, MaxBodySize: 1024 * 4},
+		}
+		httpGRPC = &httpGRPCServer{Server: srv}
+		if httpSrv, err = makeHTTPServer(httpGRPC, handlers); err != nil {
+			return fmt.Errorf("make http server: %w", err)
+		}
+	}
+
+	var adminSrv *http.Server
+	if adminLis != nil {
+		var metricsHandler http.Handler
+		if metrics != nil {
+			metricsHandler = promhttp.HandlerFor(metricsReg, promhttp.HandlerOpts{})
+		}
+		if adminSrv, err = makeAdminServer(metricsHandler, tlsCfg); err != nil {
+			return fmt.Errorf("make admin server: %w", err)
+		}
+	}
+
+	served = true
 	ewg, ctx := errgroup.WithContext(ctx)
-	ewg.Go(func() error {
-		slog.Info("listening gRPC", slog.String("addr", lis.Addr().String()))
-		healthHandler.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
-		if err := srv.Serve(lis); err != nil {
-			return fmt.Errorf("proxy server: %w", err)
+	healthHandler.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	for i, lis := range listeners {
+		addr := addrs[i]
+		slog.Info("listening gRPC",
+			slog.String("network", addr.Network),
+			slog.String("addr", lis.Addr().String()),
+			slog.Bool("tls", addr.TLS),
+			slog.String("proxy_protocol", opts.ProxyProtocol.Mode),
+			slog.Bool("http", httpSrv != nil),
+			slog.Bool("grpc_web", opts.GRPCWeb.Enable),
+			slog.Bool("connect", opts.Connect),
+			slog.Bool("rest", opts.REST),
+			slog.Bool("metrics", opts.Metrics))
+
+		if httpSrv != nil {
+			// native gRPC connections are still served by gRPC server
+			var httpLis net.Listener
+			lis, httpLis = grpcx.DemuxListener(lis, tlsCfg)
+			ewg.Go(func() error {
+				// TLS is terminated by the demultiplexer
+				if err := serveHTTP(httpSrv, httpLis, false); err != nil {
+					return fmt.Errorf("http server on %s: %w", addr, err)
+				}
+				return nil
+			})
 		}
-		return nil
-	})
+
+		ewg.Go(func() error {
+			if err := srv.Serve(lis); err != nil {
+				return fmt.Errorf("proxy server on %s: %w", addr, err)
+			}
+			return nil
+		})
+	}
+	if adminSrv != nil {
+		ewg.Go(func() error {
+			slog.Info("listening admin",
+				slog.String("network", adminAddr.Network),
+				slog.String("addr", adminLis.Addr().String()),
+				slog.Bool("tls", adminAddr.TLS))
+			if err := serveHTTP(adminSrv, adminLis, adminAddr.TLS); err != nil {
+				return fmt.Errorf("admin server on %s: %w", adminAddr, err)
+			}
+			return nil
+		})
+		ewg.Go(func() error {
+			<-ctx.Done()
+			slog.Info("shutting down admin server")
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := adminSrv.Shutdown(shutdownCtx); err != nil {
+				slog.Warn("failed to shutdown admin server gracefully", slog.Any("error", err))
+			}
+			return nil
+		})
+	}
+	if reloader != nil {
+		ewg.Go(func() error {
+			reloadCerts(ctx, reloader)
+			return nil
+		})
+	}
 	ewg.Go(func() error {
 		<-ctx.Done()
 		slog.Info("shutting down gRPC")
 		healthHandler.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+		if httpSrv != nil {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := httpSrv.Shutdown(shutdownCtx); err != nil {
+				slog.Warn("failed to shutdown http server gracefully", slog.Any("error", err))
+			}
+			// gRPC server can't gracefully stop calls served over HTTP handler
+			if !httpGRPC.wait(shutdownCtx) {
+				slog.Warn("gRPC calls over http server haven't finished in time")
+				srv.Stop()
+				return nil
+			}
+		}
 		srv.GracefulStop()
 		return nil
 	})
@@ -158,9 +452,185 @@ func run(ctx context.Context) error {
 	return nil
 }
 
+// makeCertificate returns the function to get the server certificate
+// according to the ssl type. The reloader is returned only for static certificates.
+func makeCertificate() (
+	getCert func(*tls.ClientHelloInfo) (*tls.Certificate, error),
+	reloader *tlsx.CertReloader,
+	err error,
+) {
+	if opts.SSL.Type == "self-signed" {
+		slog.Info("using self-signed ssl",
+			slog.Any("hosts", opts.SSL.Hosts),
+			slog.String("ca_out", opts.SSL.CAOut),
+			slog.String("client_ca", opts.SSL.ClientCA),
+			slog.String("client_auth", opts.SSL.ClientAuth))
+
+		cert, caPEM, err := tlsx.SelfSigned(opts.SSL.Hosts, 365*24*time.Hour)
+		if err != nil {
+			return nil, nil, fmt.Errorf("generate self-signed certificate: %w", err)
+		}
+
+		if opts.SSL.CAOut != "" {
+			if err = os.WriteFile(opts.SSL.CAOut, caPEM, 0o644); err != nil { //nolint:gosec // CA certificate is public
+				return nil, nil, fmt.Errorf("write ca certificate: %w", err)
+			}
+		}
+
+		return func(*tls.ClientHelloInfo) (*tls.Certificate, error) { return &cert, nil }, nil, nil
+	}
+
+	if opts.SSL.Cert == "" || opts.SSL.Key == "" {
+		return nil, nil, fmt.Errorf("cert and key must be provided for static ssl")
+	}
+
+	slog.Info("using static ssl",
+		slog.String("cert", opts.SSL.Cert),
+		slog.String("key", opts.SSL.Key),
+		slog.String("client_ca", opts.SSL.ClientCA),
+		slog.String("client_auth", opts.SSL.ClientAuth))
+
+	if reloader, err = tlsx.NewCertReloader(opts.SSL.Cert, opts.SSL.Key); err != nil {
+		return nil, nil, fmt.Errorf("load cert and key: %w", err)
+	}
+
+	return reloader.GetCertificate, reloader, nil
+}
+
+// reloadCerts reloads the certificate on SIGHUP and, if configured,
+// when the cert or key files change, until the context is done.
+func reloadCerts(ctx context.Context, reloader *tlsx.CertReloader) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var tick <-chan time.Time
+	if opts.SSL.ReloadInterval > 0 {
+		ticker := time.NewTicker(opts.SSL.ReloadInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			if err := reloader.Reload(); err != nil {
+				slog.Error("failed to reload certificate", slog.Any("error", err))
+				continue
+			}
+			slog.Info("reloaded certificate on SIGHUP")
+		case <-tick:
+			reloaded, err := reloader.ReloadIfModified()
+			if err != nil {
+				slog.Error("failed to reload certificate", slog.Any("error", err))
+				continue
+			}
+			if reloaded {
+				slog.Info("reloaded modified certificate")
+			}
+		}
+	}
+}
+
+func makeTLSConfig(getCert func(*tls.ClientHelloInfo) (*tls.Certificate, error)) (*tls.Config, error) {
+	cfg := &tls.Config{
+		GetCertificate: getCert,
+		MinVersion:     tls.VersionTLS12,
+	}
+
+	switch opts.SSL.ClientAuth {
+	case "request":
+		cfg.ClientAuth = tls.RequestClientCert
+	case "require":
+		cfg.ClientAuth = tls.RequireAnyClientCert
+	case "verify":
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	default:
+		cfg.ClientAuth = tls.NoClientCert
+	}
+
+	if opts.SSL.ClientCA != "" {
+		bundle, err := os.ReadFile(opts.SSL.ClientCA)
+		if err != nil {
+			return nil, fmt.Errorf("read client ca: %w", err)
+		}
+
+		cfg.ClientCAs = x509.NewCertPool()
+		if !cfg.ClientCAs.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("no certificates found in client ca %s", opts.SSL.ClientCA)
+		}
+	}
+
+	if cfg.ClientAuth == tls.RequireAndVerifyClientCert && cfg.ClientCAs == nil {
+		return nil, fmt.Errorf("client ca must be provided to verify client certificates")
+	}
+
+	return cfg, nil
+}
+
+// makeTracerProvider makes a tracer provider, which exports traces
+// to the OTLP endpoint, if it's set.
+func makeTracerProvider(ctx context.Context) (*sdktrace.TracerProvider, error) {
+	res := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(opts.OTel.ServiceName),
+		semconv.ServiceVersion(getVersion()),
+	)
+
+	tpOpts := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+	if opts.OTel.Endpoint != "" {
+		expOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.OTel.Endpoint)}
+		if opts.OTel.Insecure {
+			expOpts = append(expOpts, otlptracegrpc.WithInsecure())
+		}
+
+		exp, err := otlptracegrpc.New(ctx, expOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("make otlp exporter: %w", err)
+		}
+		tpOpts = append(tpOpts, sdktrace.WithBatcher(exp))
+	}
+
+	return sdktrace.NewTracerProvider(tpOpts...), nil
+}
+
+// makeAccessLog makes an access log, which writes either to the file,
+// if it's set, or to the main log.
+func makeAccessLog(realIP grpcx.RealIPResolver) (al grpcx.AccessLog, closeFn func(), err error) {
+	al = grpcx.AccessLog{
+		Logger:       slog.Default(),
+		Fields:       opts.AccessLog.Fields,
+		MetadataKeys: opts.AccessLog.Metadata,
+		SampleRate:   opts.AccessLog.SampleRate,
+		RealIP:       realIP,
+	}
+
+	if opts.AccessLog.File == "" {
+		return al, func() {}, nil
+	}
+
+	f, err := os.OpenFile(opts.AccessLog.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return grpcx.AccessLog{}, nil, fmt.Errorf("open access log file: %w", err)
+	}
+
+	if opts.JSON {
+		al.Logger = slog.New(slog.NewJSONHandler(f, nil))
+	} else {
+		al.Logger = slog.New(slog.NewTextHandler(f, nil))
+	}
+
+	return al, func() {
+		if err := f.Close(); err != nil {
+			slog.Warn("failed to close access log file", slog.Any("error", err))
+		}
+	}, nil
+}
+
 var setupLoggerOnce sync.Once
 
-func setupLog(dbg, json bool) {
+func setupLog(dbg, json bool, grpcSeverity string, grpcVerbosity int) {
 	defer slog.Info("prepared logger", slog.Bool("debug", dbg), slog.Bool("json", json))
 
 	handlerOpts := &slog.HandlerOptions{Level: slog.LevelInfo}
@@ -178,8 +648,16 @@ func setupLog(dbg, json bool) {
 
 	slog.SetDefault(slog.New(handler))
 	setupLoggerOnce.Do(func() {
+		minLevel := map[string]slog.Level{
+			"info":    slog.LevelInfo,
+			"warning": slog.LevelWarn,
+			"error":   slog.LevelError,
+		}[grpcSeverity]
+
 		grpclog.SetLoggerV2(grpcx.Logger{
-			Logger: slog.NewLogLogger(handler, slog.LevelDebug),
+			Handler:   handler,
+			MinLevel:  minLevel,
+			Verbosity: grpcVerbosity,
 		})
 	})
 }
@@ -17,6 +17,30 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/codes"
 	"strings"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"errors"
+	"io"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/credentials"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	crand "crypto/rand"
+	"math/big"
+	"crypto/x509/pkix"
+	"net"
+	"encoding/pem"
+	"path/filepath"
+	"github.com/pires/go-proxyproto"
+	"sync"
+	"encoding/json"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"bytes"
+	"github.com/jessevdk/go-flags"
 )
 
 func TestMain_run(t *testing.T) {
@@ -34,6 +58,8 @@ func TestMain_run(t *testing.T) {
 
 	t.Logf("response: %+v", resp)
 	assert(t, resp.Body == "hello", "unexpected response body: %+v", resp.Body)
+	// the OTLP exporter registers gzip compressor, so the client in the same binary announces it
+	delete(resp.Headers, "grpc-accept-encoding")
 	assert(t, reflect.DeepEqual(map[string]string{
 		":authority":   fmt.Sprintf("localhost:%d", port),
 		"content-type": "application/grpc",
@@ -55,6 +81,26 @@ func TestMain_run(t *testing.T) {
 		resp.HandlerRespondedAt.AsTime().Sub(now))
 }
 
+func TestMain_runClosesListenersOnFailure(t *testing.T) {
+	port, adminPort := 40000+rand.Intn(10000), 30000+rand.Intn(10000)
+	reflect.ValueOf(&opts).Elem().SetZero()
+	_, err := flags.ParseArgs(&opts, []string{
+		"--addr", ":" + strconv.Itoa(port),
+		"--admin.addr", ":" + strconv.Itoa(adminPort),
+		"--ssl.enable", "--ssl.cert", "missing.pem", "--ssl.key", "missing.pem",
+	})
+	assert(t, err == nil, "failed to parse flags: %v", err)
+
+	err = run(context.Background())
+	assert(t, err != nil, "run must fail on missing certificate")
+
+	for _, p := range []int{port, adminPort} {
+		lis, err := net.Listen("tcp", ":"+strconv.Itoa(p))
+		assert(t, err == nil, "port %d must be released: %v", p, err)
+		_ = lis.Close()
+	}
+}
+
 func TestMain_DropStream(t *testing.T) {
 	_, conn := setup(t, "--stream-timeout", "500ms")
 	defer conn.Close()
@@ -78,6 +124,1065 @@ func TestMain_DropStream(t *testing.T) {
 	assert(t, time.Since(now) < 600*time.Millisecond, "more than 600ms passed: %s", time.Since(now))
 }
 
+func TestMain_EchoStatus(t *testing.T) {
+	_, conn := setup(t)
+	defer conn.Close()
+	waitForServerUp(t, conn)
+
+	client := echopb.NewEchoServiceClient(conn)
+
+	tt := []struct {
+		name        string
+		req         *echopb.Status
+		wantCode    codes.Code
+		wantMsg     string
+		wantDetails []proto.Message
+	}{
+		{
+			name:     "no details",
+			req:      &echopb.Status{Code: uint32(codes.NotFound), Message: "not found"},
+			wantCode: codes.NotFound,
+			wantMsg:  "not found",
+		},
+		{
+			name: "retry info",
+			req: &echopb.Status{
+				Code:    uint32(codes.Unavailable),
+				Message: "try later",
+				Details: []*echopb.StatusDetail{{Detail: &echopb.StatusDetail_RetryInfo{
+					RetryInfo: &echopb.RetryInfo{RetryDelay: durationpb.New(time.Second)},
+				}}},
+			},
+			wantCode:    codes.Unavailable,
+			wantMsg:     "try later",
+			wantDetails: []proto.Message{&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Second)}},
+		},
+		{
+			name: "bad request",
+			req: &echopb.Status{
+				Code:    uint32(codes.InvalidArgument),
+				Message: "bad request",
+				Details: []*echopb.StatusDetail{{Detail: &echopb.StatusDetail_BadRequest{
+					BadRequest: &echopb.BadRequest{FieldViolations: []*echopb.BadRequest_FieldViolation{
+						{Field: "ping", Description: "must not be empty"},
+						{Field: "status", Description: "must be set"},
+					}},
+				}}},
+			},
+			wantCode: codes.InvalidArgument,
+			wantMsg:  "bad request",
+			wantDetails: []proto.Message{&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "ping", Description: "must not be empty"},
+				{Field: "status", Description: "must be set"},
+			}}},
+		},
+		{
+			name: "error info",
+			req: &echopb.Status{
+				Code:    uint32(codes.PermissionDenied),
+				Message: "denied",
+				Details: []*echopb.StatusDetail{{Detail: &echopb.StatusDetail_ErrorInfo{
+					ErrorInfo: &echopb.ErrorInfo{
+						Reason:   "API_DISABLED",
+						Domain:   "grpc-echo.semior.dev",
+						Metadata: map[string]string{"service": "echo"},
+					},
+				}}},
+			},
+			wantCode: codes.PermissionDenied,
+			wantMsg:  "denied",
+			wantDetails: []proto.Message{&errdetails.ErrorInfo{
+				Reason:   "API_DISABLED",
+				Domain:   "grpc-echo.semior.dev",
+				Metadata: map[string]string{"service": "echo"},
+			}},
+		},
+		{
+			name: "multiple details",
+			req: &echopb.Status{
+				Code:    uint32(codes.ResourceExhausted),
+				Message: "slow down",
+				Details: []*echopb.StatusDetail{
+					{Detail: &echopb.StatusDetail_ErrorInfo{ErrorInfo: &echopb.ErrorInfo{Reason: "QUOTA"}}},
+					{Detail: &echopb.StatusDetail_RetryInfo{
+						RetryInfo: &echopb.RetryInfo{RetryDelay: durationpb.New(time.Minute)},
+					}},
+				},
+			},
+			wantCode: codes.ResourceExhausted,
+			wantMsg:  "slow down",
+			wantDetails: []proto.Message{
+				&errdetails.ErrorInfo{Reason: "QUOTA"},
+				&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Minute)},
+			},
+		},
+		{
+			name:     "empty detail",
+			req:      &echopb.Status{Code: uint32(codes.Internal), Details: []*echopb.StatusDetail{{}}},
+			wantCode: codes.InvalidArgument,
+			wantMsg:  "invalid status: detail #0 is empty",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := client.Echo(context.Background(), &echopb.EchoRequest{Ping: "hello", Status: tc.req})
+			st, ok := status.FromError(err)
+			assert(t, ok, "unexpected error: %v", err)
+			assert(t, st.Code() == tc.wantCode, "unexpected code: %v", st.Code())
+			assert(t, st.Message() == tc.wantMsg, "unexpected message: %q", st.Message())
+
+			details := st.Details()
+			assert(t, len(details) == len(tc.wantDetails), "unexpected details: %v", details)
+			for i := range details {
+				assert(t, proto.Equal(details[i].(proto.Message), tc.wantDetails[i]),
+					"unexpected detail #%d: %v", i, details[i])
+			}
+		})
+	}
+
+	t.Run("ok status", func(t *testing.T) {
+		resp, err := client.Echo(context.Background(), &echopb.EchoRequest{
+			Ping:   "hello",
+			Status: &echopb.Status{Code: uint32(codes.OK)},
+		})
+		assert(t, err == nil, "unexpected error: %v", err)
+		assert(t, resp.Body == "hello", "unexpected response body: %+v", resp.Body)
+	})
+}
+
+func TestMain_EchoDelay(t *testing.T) {
+	_, conn := setup(t)
+	defer conn.Close()
+	waitForServerUp(t, conn)
+
+	client := echopb.NewEchoServiceClient(conn)
+
+	tt := []struct {
+		name     string
+		delay    *echopb.Delay
+		min, max time.Duration
+	}{
+		{
+			name:  "fixed",
+			delay: &echopb.Delay{Min: durationpb.New(100 * time.Millisecond)},
+			min:   100 * time.Millisecond, max: 150 * time.Millisecond,
+		},
+		{
+			name: "uniform",
+			delay: &echopb.Delay{
+				Min: durationpb.New(50 * time.Millisecond),
+				Max: durationpb.New(100 * time.Millisecond),
+			},
+			min: 50 * time.Millisecond, max: 150 * time.Millisecond,
+		},
+		{
+			name: "normal",
+			delay: &echopb.Delay{
+				Min:          durationpb.New(50 * time.Millisecond),
+				Max:          durationpb.New(100 * time.Millisecond),
+				Distribution: echopb.Delay_DISTRIBUTION_NORMAL,
+			},
+			min: 50 * time.Millisecond, max: 150 * time.Millisecond,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			now := time.Now()
+			resp, err := client.Echo(context.Background(), &echopb.EchoRequest{Ping: "hello", Delay: tc.delay})
+			assert(t, err == nil, "unexpected error: %v", err)
+			assert(t, resp.Body == "hello", "unexpected response body: %+v", resp.Body)
+
+			elapsed, delay := time.Since(now), resp.Delay.AsDuration()
+			assert(t, elapsed >= tc.min && elapsed < tc.max, "unexpected elapsed time: %s", elapsed)
+			assert(t, delay >= tc.min && delay <= elapsed, "unexpected reported delay: %s", delay)
+		})
+	}
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		now := time.Now()
+		_, err := client.Echo(ctx, &echopb.EchoRequest{
+			Ping:  "hello",
+			Delay: &echopb.Delay{Min: durationpb.New(time.Second)},
+		})
+		assert(t, status.Code(err) == codes.DeadlineExceeded, "unexpected error: %v", err)
+		assert(t, time.Since(now) < 100*time.Millisecond, "more than 100ms passed: %s", time.Since(now))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, delay := range map[string]*echopb.Delay{
+			"negative min": {Min: durationpb.New(-time.Second), Max: durationpb.New(time.Second)},
+			"negative max": {Min: durationpb.New(time.Second), Max: durationpb.New(-time.Second)},
+			"overflowing span": {
+				Min: &durationpb.Duration{Seconds: -315576000000},
+				Max: &durationpb.Duration{Seconds: 315576000000},
+			},
+			"too large": {Max: &durationpb.Duration{Seconds: 315576000000}, Distribution: echopb.Delay_DISTRIBUTION_NORMAL},
+			"malformed": {Min: &durationpb.Duration{Seconds: 1, Nanos: -1}},
+		} {
+			now := time.Now()
+			_, err := client.Echo(context.Background(), &echopb.EchoRequest{Ping: "hello", Delay: delay})
+			assert(t, status.Code(err) == codes.InvalidArgument, "%s: unexpected error: %v", name, err)
+			assert(t, time.Since(now) < 100*time.Millisecond, "%s: more than 100ms passed: %s", name, time.Since(now))
+		}
+	})
+}
+
+func TestMain_EchoResponseMetadata(t *testing.T) {
+	_, conn := setup(t)
+	defer conn.Close()
+	waitForServerUp(t, conn)
+
+	client := echopb.NewEchoServiceClient(conn)
+
+	req := &echopb.EchoRequest{
+		Ping:             "hello",
+		ResponseHeaders:  map[string]string{"X-Header": "header-value", "x-header-bin": "\x00\x01"},
+		ResponseTrailers: map[string]string{"x-trailer": "trailer-value"},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		var header, trailer metadata.MD
+		resp, err := client.Echo(context.Background(), req, grpc.Header(&header), grpc.Trailer(&trailer))
+		assert(t, err == nil, "unexpected error: %v", err)
+		assert(t, resp.Body == "hello", "unexpected response body: %+v", resp.Body)
+
+		assert(t, reflect.DeepEqual(header.Get("x-header"), []string{"header-value"}),
+			"unexpected header: %v", header)
+		assert(t, reflect.DeepEqual(header.Get("x-header-bin"), []string{"\x00\x01"}),
+			"unexpected binary header: %v", header)
+		assert(t, reflect.DeepEqual(trailer.Get("x-trailer"), []string{"trailer-value"}),
+			"unexpected trailer: %v", trailer)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		req := proto.Clone(req).(*echopb.EchoRequest)
+		req.Status = &echopb.Status{Code: uint32(codes.Unavailable)}
+
+		var header, trailer metadata.MD
+		_, err := client.Echo(context.Background(), req, grpc.Header(&header), grpc.Trailer(&trailer))
+		assert(t, status.Code(err) == codes.Unavailable, "unexpected error: %v", err)
+		assert(t, reflect.DeepEqual(header.Get("x-header"), []string{"header-value"}),
+			"unexpected header: %v", header)
+		assert(t, reflect.DeepEqual(trailer.Get("x-trailer"), []string{"trailer-value"}),
+			"unexpected trailer: %v", trailer)
+	})
+}
+
+func TestMain_EchoMetadata(t *testing.T) {
+	_, conn := setup(t)
+	defer conn.Close()
+	waitForServerUp(t, conn)
+
+	client := echopb.NewEchoServiceClient(conn)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		"x-multi", "a,b",
+		"x-multi", "c",
+		"x-data-bin", "\x00\x7f,\x01",
+		"x-data-bin", "\x02",
+		"x-invalid-bin", "\xff",
+	)
+
+	resp, err := client.Echo(ctx, &echopb.EchoRequest{Ping: "hello"})
+	assert(t, err == nil, "unexpected error: %v", err)
+
+	assert(t, proto.Equal(resp.Metadata["x-multi"], &echopb.MetadataValues{Values: []string{"a,b", "c"}}),
+		"unexpected x-multi metadata: %v", resp.Metadata["x-multi"])
+	assert(t, proto.Equal(resp.Metadata["x-data-bin"], &echopb.MetadataValues{
+		BinaryValues: [][]byte{[]byte("\x00\x7f,\x01"), []byte("\x02")},
+	}), "unexpected x-data-bin metadata: %v", resp.Metadata["x-data-bin"])
+	assert(t, resp.Headers["x-data-bin"] == "\x00\x7f,\x01,\x02", "unexpected x-data-bin header: %q", resp.Headers["x-data-bin"])
+	_, ok := resp.Headers["x-invalid-bin"]
+	assert(t, !ok, "invalid utf-8 binary value must not be in headers")
+	assert(t, bytes.Equal(resp.Metadata["x-invalid-bin"].GetBinaryValues()[0], []byte{0xff}),
+		"unexpected x-invalid-bin metadata: %v", resp.Metadata["x-invalid-bin"])
+	assert(t, reflect.DeepEqual(resp.Metadata["user-agent"].GetValues(),
+		[]string{fmt.Sprintf("grpc-echo-test-ua grpc-go/%s", grpc.Version)}),
+		"unexpected user-agent metadata: %v", resp.Metadata["user-agent"])
+}
+
+func TestMain_EchoDeadline(t *testing.T) {
+	_, conn := setup(t)
+	defer conn.Close()
+	waitForServerUp(t, conn)
+
+	client := echopb.NewEchoServiceClient(conn)
+
+	t.Run("with deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		deadline, _ := ctx.Deadline()
+		resp, err := client.Echo(ctx, &echopb.EchoRequest{Ping: "hello"})
+		assert(t, err == nil, "unexpected error: %v", err)
+
+		t.Logf("deadline: %s, remaining: %s, grpc-timeout: %s",
+			resp.Deadline.AsTime(), resp.DeadlineRemaining.AsDuration(), resp.GrpcTimeout)
+
+		const threshold = 10 * time.Millisecond
+		diff := deadline.Sub(resp.Deadline.AsTime())
+		assert(t, diff >= -threshold && diff <= threshold, "unexpected deadline: %s, want %s",
+			resp.Deadline.AsTime(), deadline)
+
+		remaining := resp.DeadlineRemaining.AsDuration()
+		assert(t, remaining > 5*time.Second-threshold && remaining <= 5*time.Second,
+			"unexpected remaining time: %s", remaining)
+		assert(t, strings.HasSuffix(resp.GrpcTimeout, "u") || strings.HasSuffix(resp.GrpcTimeout, "m"),
+			"unexpected grpc-timeout: %s", resp.GrpcTimeout)
+	})
+
+	t.Run("without deadline", func(t *testing.T) {
+		resp, err := client.Echo(context.Background(), &echopb.EchoRequest{Ping: "hello"})
+		assert(t, err == nil, "unexpected error: %v", err)
+		assert(t, resp.Deadline == nil, "unexpected deadline: %s", resp.Deadline)
+		assert(t, resp.DeadlineRemaining == nil, "unexpected remaining time: %s", resp.DeadlineRemaining)
+		assert(t, resp.GrpcTimeout == "", "unexpected grpc-timeout: %s", resp.GrpcTimeout)
+	})
+
+	t.Run("stream without deadline", func(t *testing.T) {
+		// stream timeout of the server must not be reported as the deadline of the call
+		stream, err := client.EchoBidi(context.Background())
+		assert(t, err == nil, "unexpected error: %v", err)
+		defer stream.CloseSend()
+
+		err = stream.Send(&echopb.EchoRequest{Ping: "hello"})
+		assert(t, err == nil, "unexpected error: %v", err)
+		resp, err := stream.Recv()
+		assert(t, err == nil, "unexpected error: %v", err)
+		assert(t, resp.Deadline == nil, "unexpected deadline: %s", resp.Deadline)
+		assert(t, resp.GrpcTimeout == "", "unexpected grpc-timeout: %s", resp.GrpcTimeout)
+	})
+
+	t.Run("stream with deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		deadline, _ := ctx.Deadline()
+		stream, err := client.EchoBidi(ctx)
+		assert(t, err == nil, "unexpected error: %v", err)
+		defer stream.CloseSend()
+
+		err = stream.Send(&echopb.EchoRequest{Ping: "hello"})
+		assert(t, err == nil, "unexpected error: %v", err)
+		resp, err := stream.Recv()
+		assert(t, err == nil, "unexpected error: %v", err)
+
+		const threshold = 10 * time.Millisecond
+		diff := deadline.Sub(resp.Deadline.AsTime())
+		assert(t, diff >= -threshold && diff <= threshold, "unexpected deadline: %s, want %s",
+			resp.Deadline.AsTime(), deadline)
+	})
+}
+
+func TestMain_EchoTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	pool := genCert(t, "localhost", certFile, keyFile)
+
+	_, conn := setupWithCreds(t,
+		credentials.NewTLS(&tls.Config{RootCAs: pool, ServerName: "localhost", MinVersion: tls.VersionTLS13}),
+		"--ssl.enable", "--ssl.cert", certFile, "--ssl.key", keyFile)
+	defer conn.Close()
+	waitForServerUp(t, conn)
+
+	client := echopb.NewEchoServiceClient(conn)
+	resp, err := client.Echo(context.Background(), &echopb.EchoRequest{Ping: "hello"})
+	assert(t, err == nil, "unexpected error: %v", err)
+
+	t.Logf("tls: %+v", resp.Tls)
+	assert(t, proto.Equal(resp.Tls, &echopb.TLSInfo{
+		Version:     "TLS 1.3",
+		CipherSuite: resp.Tls.GetCipherSuite(),
+		Alpn:        "h2",
+		ServerName:  "localhost",
+	}), "unexpected tls info: %v", resp.Tls)
+	assert(t, strings.HasPrefix(resp.Tls.CipherSuite, "TLS_"), "unexpected cipher suite: %s", resp.Tls.CipherSuite)
+}
+
+func TestMain_EchoMTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	clientCertFile, clientKeyFile := filepath.Join(dir, "client-cert.pem"), filepath.Join(dir, "client-key.pem")
+	pool := genCert(t, "localhost", certFile, keyFile)
+	genCert(t, "client", clientCertFile, clientKeyFile)
+
+	clientCert, err := tls.LoadX509KeyPair(clientCertFile, clientKeyFile)
+	assert(t, err == nil, "failed to load client cert: %v", err)
+
+	tt := []struct {
+		name         string
+		mode         string
+		certs        []tls.Certificate
+		wantErr      string
+		wantVerified bool
+	}{
+		{name: "verify", mode: "verify", certs: []tls.Certificate{clientCert}, wantVerified: true},
+		{name: "verify without cert", mode: "verify", wantErr: "certificate required"},
+		{name: "require", mode: "require", certs: []tls.Certificate{clientCert}},
+		{name: "request", mode: "request", certs: []tls.Certificate{clientCert}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, conn := setupWithCreds(t,
+				credentials.NewTLS(&tls.Config{RootCAs: pool, Certificates: tc.certs, MinVersion: tls.VersionTLS13}),
+				"--ssl.enable", "--ssl.cert", certFile, "--ssl.key", keyFile,
+				"--ssl.client-ca", clientCertFile, "--ssl.client-auth", tc.mode)
+			defer conn.Close()
+
+			client := echopb.NewEchoServiceClient(conn)
+
+			var (
+				resp *echopb.EchoResponse
+				err  error
+			)
+			for i := 0; i < 20; i++ { // wait for the server to start
+				resp, err = client.Echo(context.Background(), &echopb.EchoRequest{Ping: "hello"})
+				if err == nil || tc.wantErr != "" && strings.Contains(err.Error(), tc.wantErr) {
+					break
+				}
+				time.Sleep(100 * time.Millisecond)
+			}
+
+			if tc.wantErr != "" {
+				assert(t, err != nil && strings.Contains(err.Error(), tc.wantErr), "unexpected error: %v", err)
+				return
+			}
+
+			assert(t, err == nil, "unexpected error: %v", err)
+			t.Logf("tls: %+v", resp.Tls)
+			assert(t, resp.Tls.ClientVerified == tc.wantVerified, "unexpected verified: %v", resp.Tls.ClientVerified)
+			assert(t, proto.Equal(resp.Tls.ClientCertificate, &echopb.Certificate{
+				Subject:     "CN=client",
+				Issuer:      "CN=client",
+				DnsNames:    []string{"client"},
+				IpAddresses: []string{"127.0.0.1", "::1"},
+			}), "unexpected client certificate: %v", resp.Tls.ClientCertificate)
+		})
+	}
+}
+
+func TestMain_ReloadCert(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	oldPool := genCert(t, "localhost", certFile, keyFile)
+
+	_, conn := setupWithCreds(t,
+		credentials.NewTLS(&tls.Config{RootCAs: oldPool, MinVersion: tls.VersionTLS13}),
+		"--ssl.enable", "--ssl.cert", certFile, "--ssl.key", keyFile, "--ssl.reload-interval", "50ms")
+	defer conn.Close()
+	waitForServerUp(t, conn)
+
+	client := echopb.NewEchoServiceClient(conn)
+	_, err := client.Echo(context.Background(), &echopb.EchoRequest{Ping: "hello"})
+	assert(t, err == nil, "unexpected error: %v", err)
+
+	// make sure the modification time changes
+	time.Sleep(10 * time.Millisecond)
+	newPool := genCert(t, "localhost", certFile, keyFile)
+
+	// wait for the server to reload the certificate
+	var newResp *echopb.EchoResponse
+	for i := 0; i < 20; i++ {
+		time.Sleep(50 * time.Millisecond)
+
+		newConn, err := grpc.NewClient(conn.Target(),
+			grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: newPool, MinVersion: tls.VersionTLS13})))
+		assert(t, err == nil, "failed to create client: %v", err)
+
+		newResp, err = echopb.NewEchoServiceClient(newConn).Echo(context.Background(), &echopb.EchoRequest{Ping: "hello"})
+		_ = newConn.Close()
+		if err == nil {
+			break
+		}
+	}
+	assert(t, newResp != nil, "server didn't serve the new certificate")
+
+	// existing connection must keep working
+	_, err = client.Echo(context.Background(), &echopb.EchoRequest{Ping: "hello"})
+	assert(t, err == nil, "unexpected error on existing connection: %v", err)
+}
+
+func TestMain_SelfSignedCert(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+
+	_, conn := setupWithCreds(t,
+		credentials.NewTLS(&tls.Config{
+			MinVersion: tls.VersionTLS13,
+			ServerName: "echo.local",
+			// the CA is written by the server at startup, thus the chain is verified manually
+			InsecureSkipVerify: true, //nolint:gosec // verified in VerifyConnection
+			VerifyConnection: func(cs tls.ConnectionState) error {
+				caPEM, err := os.ReadFile(caFile)
+				if err != nil {
+					return fmt.Errorf("read ca: %w", err)
+				}
+				pool := x509.NewCertPool()
+				if !pool.AppendCertsFromPEM(caPEM) {
+					return errors.New("no certificates in ca")
+				}
+				_, err = cs.PeerCertificates[0].Verify(x509.VerifyOptions{DNSName: cs.ServerName, Roots: pool})
+				return err
+			},
+		}),
+		"--ssl.enable", "--ssl.type", "self-signed", "--ssl.ca-out", caFile,
+		"--ssl.host", "echo.local", "--ssl.host", "127.0.0.1")
+	defer conn.Close()
+	waitForServerUp(t, conn)
+
+	client := echopb.NewEchoServiceClient(conn)
+	resp, err := client.Echo(context.Background(), &echopb.EchoRequest{Ping: "hello"})
+	assert(t, err == nil, "unexpected error: %v", err)
+	assert(t, resp.Tls.GetServerName() == "echo.local", "unexpected tls info: %v", resp.Tls)
+}
+
+func TestMain_EchoStream(t *testing.T) {
+	_, conn := setup(t, "--stream-timeout", "500ms")
+	defer conn.Close()
+	waitForServerUp(t, conn)
+
+	client := echopb.NewEchoServiceClient(conn)
+
+	t.Run("sends requested number of responses", func(t *testing.T) {
+		now := time.Now()
+		stream, err := client.EchoStream(context.Background(), &echopb.EchoStreamRequest{
+			Ping:     "hello",
+			Count:    3,
+			Interval: durationpb.New(50 * time.Millisecond),
+		})
+		assert(t, err == nil, "failed to create stream: %v", err)
+
+		for seq := uint64(1); seq <= 3; seq++ {
+			resp, err := stream.Recv()
+			assert(t, err == nil, "failed to recv: %v", err)
+			assert(t, resp.Seq == seq, "unexpected seq: %d, want %d", resp.Seq, seq)
+			assert(t, resp.Body == "hello", "unexpected response body: %+v", resp.Body)
+			assert(t, resp.SentAt.AsTime().After(resp.ReceivedAt.AsTime()),
+				"sent_at %s must be after received_at %s", resp.SentAt.AsTime(), resp.ReceivedAt.AsTime())
+		}
+
+		_, err = stream.Recv()
+		assert(t, errors.Is(err, io.EOF), "expected EOF, got: %v", err)
+		assert(t, time.Since(now) >= 100*time.Millisecond, "less than 100ms passed: %s", time.Since(now))
+	})
+
+	t.Run("stops on stream timeout", func(t *testing.T) {
+		stream, err := client.EchoStream(context.Background(), &echopb.EchoStreamRequest{
+			Ping:     "hello",
+			Interval: durationpb.New(100 * time.Millisecond),
+		})
+		assert(t, err == nil, "failed to create stream: %v", err)
+
+		for {
+			if _, err = stream.Recv(); err != nil {
+				break
+			}
+		}
+		assert(t, status.Code(err) == codes.DeadlineExceeded, "unexpected error: %v", err)
+	})
+
+	t.Run("stops on client cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+		defer cancel()
+
+		stream, err := client.EchoStream(ctx, &echopb.EchoStreamRequest{
+			Ping:     "hello",
+			Interval: durationpb.New(100 * time.Millisecond),
+		})
+		assert(t, err == nil, "failed to create stream: %v", err)
+
+		received := 0
+		for {
+			if _, err = stream.Recv(); err != nil {
+				break
+			}
+			received++
+		}
+		assert(t, status.Code(err) == codes.DeadlineExceeded, "unexpected error: %v", err)
+		assert(t, received == 2, "unexpected number of responses: %d", received)
+	})
+
+	t.Run("rejects unbounded stream without interval", func(t *testing.T) {
+		for _, req := range []*echopb.EchoStreamRequest{
+			{Ping: "hello"},
+			{Ping: "hello", Count: 3, Interval: durationpb.New(-time.Second)},
+		} {
+			stream, err := client.EchoStream(context.Background(), req)
+			assert(t, err == nil, "failed to create stream: %v", err)
+			_, err = stream.Recv()
+			assert(t, status.Code(err) == codes.InvalidArgument, "unexpected error: %v", err)
+		}
+	})
+}
+
+func TestMain_Collect(t *testing.T) {
+	_, conn := setup(t)
+	defer conn.Close()
+	waitForServerUp(t, conn)
+
+	client := echopb.NewEchoServiceClient(conn)
+
+	now := time.Now()
+	stream, err := client.Collect(context.Background())
+	assert(t, err == nil, "failed to create stream: %v", err)
+
+	pings := []string{"hello", "world", "!"}
+	for _, ping := range pings {
+		err = stream.Send(&echopb.EchoRequest{Ping: ping})
+		assert(t, err == nil, "failed to send: %v", err)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	resp, err := stream.CloseAndRecv()
+	assert(t, err == nil, "failed to close stream: %v", err)
+
+	t.Logf("response: %+v", resp)
+	assert(t, reflect.DeepEqual(pings, resp.Pings), "unexpected pings: %v", resp.Pings)
+	assert(t, resp.Count == 3, "unexpected count: %d", resp.Count)
+	assert(t, resp.TotalBytes == 11, "unexpected total bytes: %d", resp.TotalBytes)
+	assert(t, resp.FirstReceivedAt.AsTime().After(now), "first_received_at must be after start: %s",
+		resp.FirstReceivedAt.AsTime())
+	assert(t, resp.LastReceivedAt.AsTime().Sub(resp.FirstReceivedAt.AsTime()) >= 20*time.Millisecond,
+		"last_received_at must be at least 20ms after first_received_at: %s - %s",
+		resp.LastReceivedAt.AsTime(), resp.FirstReceivedAt.AsTime())
+}
+
+func TestMain_EchoBidi(t *testing.T) {
+	_, conn := setup(t)
+	defer conn.Close()
+	waitForServerUp(t, conn)
+
+	client := echopb.NewEchoServiceClient(conn)
+
+	stream, err := client.EchoBidi(context.Background())
+	assert(t, err == nil, "failed to create stream: %v", err)
+
+	for seq, ping := range []string{"hello", "world", "!"} {
+		sentAt := time.Now()
+		err = stream.Send(&echopb.EchoRequest{Ping: ping})
+		assert(t, err == nil, "failed to send: %v", err)
+
+		resp, err := stream.Recv()
+		assert(t, err == nil, "failed to recv: %v", err)
+		assert(t, resp.Body == ping, "unexpected response body: %+v", resp.Body)
+		assert(t, resp.Seq == uint64(seq+1), "unexpected seq: %d, want %d", resp.Seq, seq+1)
+		assert(t, resp.ReceivedAt.AsTime().After(sentAt), "received_at %s must be after sent at %s",
+			resp.ReceivedAt.AsTime(), sentAt)
+		assert(t, !resp.SentAt.AsTime().Before(resp.ReceivedAt.AsTime()),
+			"sent_at %s must not be before received_at %s", resp.SentAt.AsTime(), resp.ReceivedAt.AsTime())
+	}
+
+	err = stream.CloseSend()
+	assert(t, err == nil, "failed to close stream: %v", err)
+
+	_, err = stream.Recv()
+	assert(t, errors.Is(err, io.EOF), "expected EOF, got: %v", err)
+}
+
+func TestMain_MultipleListeners(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	pool := genCert(t, "localhost", certFile, keyFile)
+
+	sockFile := filepath.Join(dir, "echo.sock")
+	abstract := fmt.Sprintf("grpc-echo-test-%d", rand.Int63())
+
+	for _, mode := range []string{"grpc", "http"} {
+		t.Run(mode, func(t *testing.T) {
+			flags := []string{"--ssl.enable", "--ssl.cert", certFile, "--ssl.key", keyFile,
+				"--addr", "unix://" + sockFile + "?tls=false",
+				"--addr", "unix://@" + abstract + "?tls=false"}
+			if mode == "http" {
+				flags = append(flags, "--http")
+			}
+
+			_, conn := setupWithCreds(t,
+				credentials.NewTLS(&tls.Config{RootCAs: pool, ServerName: "localhost", MinVersion: tls.VersionTLS13}),
+				flags...)
+			defer conn.Close()
+			waitForServerUp(t, conn)
+
+			resp, err := echopb.NewEchoServiceClient(conn).Echo(context.Background(), &echopb.EchoRequest{Ping: "hello"})
+			assert(t, err == nil, "unexpected error: %v", err)
+			assert(t, resp.Tls.GetVersion() == "TLS 1.3", "unexpected tls info: %v", resp.Tls)
+
+			for _, target := range []string{"unix://" + sockFile, "unix-abstract:" + abstract} {
+				uconn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				assert(t, err == nil, "failed to create client for %s: %v", target, err)
+
+				resp, err = echopb.NewEchoServiceClient(uconn).Echo(context.Background(), &echopb.EchoRequest{Ping: "hello"})
+				assert(t, err == nil, "unexpected error for %s: %v", target, err)
+				assert(t, resp.Body == "hello", "unexpected response body for %s: %s", target, resp.Body)
+				assert(t, resp.Tls == nil, "unexpected tls info for %s: %v", target, resp.Tls)
+				_ = uconn.Close()
+			}
+		})
+	}
+}
+
+func TestMain_ProxyProtocol(t *testing.T) {
+	clientAddr := &net.TCPAddr{IP: net.ParseIP("203.0.113.7"), Port: 12345}
+
+	// dial returns the dialer, which sends PROXY header of the given version,
+	// if it's not zero, before the gRPC connection preface
+	dial := func(version byte) func(ctx context.Context, addr string) (net.Conn, error) {
+		return func(ctx context.Context, addr string) (net.Conn, error) {
+			conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+			if err != nil || version == 0 {
+				return conn, err
+			}
+			header := proxyproto.HeaderProxyFromAddrs(version, clientAddr, conn.RemoteAddr())
+			if _, err = header.WriteTo(conn); err != nil {
+				_ = conn.Close()
+				return nil, err
+			}
+			return conn, nil
+		}
+	}
+
+	tbl := []struct {
+		name    string
+		flags   []string
+		version byte
+		wantIP  string
+		wantErr bool
+	}{
+		{name: "v1", flags: []string{"--proxy-protocol.mode", "required"}, version: 1, wantIP: "203.0.113.7"},
+		{name: "v2", flags: []string{"--proxy-protocol.mode", "optional"}, version: 2, wantIP: "203.0.113.7"},
+		{name: "optional without header", flags: []string{"--proxy-protocol.mode", "optional"}, wantIP: "127.0.0.1"},
+		{name: "required without header", flags: []string{"--proxy-protocol.mode", "required"}, wantErr: true},
+		{name: "trusted", version: 2, wantIP: "203.0.113.7",
+			flags: []string{"--proxy-protocol.mode", "required", "--proxy-protocol.trusted-cidr", "127.0.0.0/8"}},
+		{name: "untrusted optional", version: 2, wantIP: "127.0.0.1",
+			flags: []string{"--proxy-protocol.mode", "optional", "--proxy-protocol.trusted-cidr", "10.0.0.1"}},
+		{name: "untrusted required", version: 2, wantErr: true,
+			flags: []string{"--proxy-protocol.mode", "required", "--proxy-protocol.trusted-cidr", "10.0.0.0/8"}},
+	}
+
+	for _, tt := range tbl {
+		t.Run(tt.name, func(t *testing.T) {
+			port, conn := setup(t, tt.flags...)
+			_ = conn.Close()
+
+			// dial IPv4 explicitly, as the expected addresses and trusted CIDRs are IPv4 ones
+			conn, err := grpc.NewClient(fmt.Sprintf("127.0.0.1:%d", port),
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+				grpc.WithContextDialer(dial(tt.version)))
+			assert(t, err == nil, "failed to create client: %v", err)
+			defer conn.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			resp, err := echopb.NewEchoServiceClient(conn).Echo(ctx, &echopb.EchoRequest{Ping: "hello"}, grpc.WaitForReady(true))
+			if tt.wantErr {
+				assert(t, err != nil, "expected error, got response: %v", resp)
+				return
+			}
+			assert(t, err == nil, "unexpected error: %v", err)
+			assert(t, resp.RemoteAddr == tt.wantIP, "unexpected remote addr: %s", resp.RemoteAddr)
+		})
+	}
+}
+
+func TestMain_RealIP(t *testing.T) {
+	trusted := []string{"--real-ip.trusted-proxy", "127.0.0.0/8", "--real-ip.trusted-proxy", "::1",
+		"--real-ip.trusted-proxy", "10.0.0.0/8"}
+
+	tbl := []struct {
+		name   string
+		flags  []string
+		md     []string
+		wantIP string
+	}{
+		{name: "untrusted peer", md: []string{"x-forwarded-for", "198.51.100.1"}},
+		{name: "no headers", flags: trusted},
+		{name: "single hop", flags: trusted, md: []string{"x-forwarded-for", "198.51.100.1"}, wantIP: "198.51.100.1"},
+		{name: "trusted hops are skipped", flags: trusted,
+			md:     []string{"x-forwarded-for", "198.51.100.1, 10.0.0.2", "x-forwarded-for", "10.0.0.1"},
+			wantIP: "198.51.100.1"},
+		{name: "stops at first untrusted hop", flags: trusted,
+			md: []string{"x-forwarded-for", "198.51.100.1, 203.0.113.9, 10.0.0.1"}, wantIP: "203.0.113.9"},
+		{name: "all hops are trusted", flags: trusted,
+			md: []string{"x-forwarded-for", "10.0.0.2, 10.0.0.1"}, wantIP: "10.0.0.2"},
+		{name: "broken chain", flags: trusted,
+			md: []string{"x-forwarded-for", "198.51.100.1, unknown, 10.0.0.1"}, wantIP: "10.0.0.1"},
+		{name: "precedence", flags: trusted,
+			md: []string{"x-real-ip", "198.51.100.2", "x-forwarded-for", "198.51.100.1"}, wantIP: "198.51.100.1"},
+		{name: "fallback header", flags: trusted, md: []string{"x-real-ip", "198.51.100.2"}, wantIP: "198.51.100.2"},
+		{name: "forwarded", flags: append([]string{"--real-ip.header", "Forwarded"}, trusted...),
+			md: []string{
+				"forwarded", `for="[2001:db8::17]:4711";proto=https, for=10.0.0.2`,
+				"forwarded", "for=10.0.0.1;by=10.0.0.3",
+				"x-forwarded-for", "198.51.100.1",
+			},
+			wantIP: "2001:db8::17"},
+	}
+
+	for _, tt := range tbl {
+		t.Run(tt.name, func(t *testing.T) {
+			_, conn := setup(t, tt.flags...)
+			defer conn.Close()
+			waitForServerUp(t, conn)
+
+			ctx := metadata.AppendToOutgoingContext(context.Background(), tt.md...)
+			resp, err := echopb.NewEchoServiceClient(conn).Echo(ctx, &echopb.EchoRequest{Ping: "hello"})
+			assert(t, err == nil, "unexpected error: %v", err)
+
+			if tt.wantIP == "" {
+				assert(t, resp.RemoteAddr == "::1" || resp.RemoteAddr == "127.0.0.1",
+					"unexpected remote addr: %s", resp.RemoteAddr)
+				return
+			}
+			assert(t, resp.RemoteAddr == tt.wantIP, "unexpected remote addr: %s", resp.RemoteAddr)
+		})
+	}
+}
+
+func TestMain_EchoForwarded(t *testing.T) {
+	_, conn := setup(t)
+	defer conn.Close()
+	waitForServerUp(t, conn)
+
+	tbl := []struct {
+		name string
+		md   []string
+		want []*echopb.ForwardedHop
+	}{
+		{name: "no headers"},
+		{
+			name: "forwarded",
+			md: []string{
+				"forwarded", `for="[2001:db8::17]:4711";proto=https;host=example.com, for=10.0.0.2;by=10.0.0.3`,
+				"forwarded", `For=unknown;By="_proxy"`,
+				"x-forwarded-for", "198.51.100.1",
+			},
+			want: []*echopb.ForwardedHop{
+				{For: "[2001:db8::17]:4711", Proto: "https", Host: "example.com"},
+				{For: "10.0.0.2", By: "10.0.0.3", Private: true},
+				{For: "unknown", By: "_proxy"},
+			},
+		},
+		{
+			name: "x-forwarded",
+			md: []string{
+				"x-forwarded-for", "198.51.100.1, 192.168.1.1",
+				"x-forwarded-for", "fd00::1",
+				"x-forwarded-proto", "https",
+				"x-forwarded-host", "example.com",
+			},
+			want: []*echopb.ForwardedHop{
+				{For: "198.51.100.1", Proto: "https", Host: "example.com"},
+				{For: "192.168.1.1", Private: true},
+				{For: "fd00::1", Private: true},
+			},
+		},
+	}
+
+	for _, tt := range tbl {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.AppendToOutgoingContext(context.Background(), tt.md...)
+			resp, err := echopb.NewEchoServiceClient(conn).Echo(ctx, &echopb.EchoRequest{Ping: "hello"})
+			assert(t, err == nil, "unexpected error: %v", err)
+
+			assert(t, len(resp.Forwarded) == len(tt.want), "unexpected hops: %v", resp.Forwarded)
+			for i := range tt.want {
+				assert(t, proto.Equal(resp.Forwarded[i], tt.want[i]), "unexpected hop #%d: %v", i, resp.Forwarded[i])
+			}
+		})
+	}
+}
+
+func TestMain_Tracing(t *testing.T) {
+	collector := &traceCollector{}
+	lis, err := net.Listen("tcp", "localhost:0")
+	assert(t, err == nil, "failed to listen: %v", err)
+	collectorSrv := grpc.NewServer()
+	coltracepb.RegisterTraceServiceServer(collectorSrv, collector)
+	go func() { _ = collectorSrv.Serve(lis) }()
+	t.Cleanup(collectorSrv.Stop) // after the echo server flushes its spans
+
+	t.Setenv("OTEL_BSP_SCHEDULE_DELAY", "50") // export spans right away
+
+	_, conn := setup(t, "--otel.endpoint", lis.Addr().String(), "--otel.insecure")
+	defer conn.Close()
+	waitForServerUp(t, conn)
+
+	client := echopb.NewEchoServiceClient(conn)
+
+	t.Run("traceparent", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(),
+			"traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			"tracestate", "vendor=value")
+		resp, err := client.Echo(ctx, &echopb.EchoRequest{Ping: "hello"})
+		assert(t, err == nil, "unexpected error: %v", err)
+
+		assert(t, resp.Trace.GetTraceId() == "4bf92f3577b34da6a3ce929d0e0e4736", "unexpected trace: %v", resp.Trace)
+		assert(t, resp.Trace.GetParentSpanId() == "00f067aa0ba902b7", "unexpected trace: %v", resp.Trace)
+		assert(t, resp.Trace.GetTraceState() == "vendor=value", "unexpected trace: %v", resp.Trace)
+		assert(t, resp.Trace.GetSampled(), "unexpected trace: %v", resp.Trace)
+		assert(t, resp.Trace.GetSpanId() != "" && resp.Trace.GetSpanId() != "00f067aa0ba902b7",
+			"unexpected trace: %v", resp.Trace)
+
+		var span *tracepb.Span
+		for i := 0; i < 50 && span == nil; i++ {
+			time.Sleep(50 * time.Millisecond)
+			span = collector.find(resp.Trace.SpanId)
+		}
+		assert(t, span != nil, "span %s is not exported", resp.Trace.SpanId)
+		assert(t, fmt.Sprintf("%x", span.TraceId) == resp.Trace.TraceId, "unexpected trace id: %x", span.TraceId)
+		assert(t, fmt.Sprintf("%x", span.ParentSpanId) == resp.Trace.ParentSpanId, "unexpected parent: %x", span.ParentSpanId)
+		assert(t, span.Name == "grpc_echo.v1.EchoService/Echo", "unexpected span name: %s", span.Name)
+		assert(t, span.Kind == tracepb.Span_SPAN_KIND_SERVER, "unexpected span kind: %v", span.Kind)
+	})
+
+	t.Run("b3", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(),
+			"b3", "80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-1")
+		resp, err := client.Echo(ctx, &echopb.EchoRequest{Ping: "hello"})
+		assert(t, err == nil, "unexpected error: %v", err)
+		assert(t, resp.Trace.GetTraceId() == "80f198ee56343ba864fe8b2a57d3eff7", "unexpected trace: %v", resp.Trace)
+		assert(t, resp.Trace.GetParentSpanId() == "e457b5a2e4d86bd1", "unexpected trace: %v", resp.Trace)
+	})
+
+	t.Run("new trace", func(t *testing.T) {
+		resp, err := client.Echo(context.Background(), &echopb.EchoRequest{Ping: "hello"})
+		assert(t, err == nil, "unexpected error: %v", err)
+		assert(t, len(resp.Trace.GetTraceId()) == 32, "unexpected trace: %v", resp.Trace)
+		assert(t, resp.Trace.GetParentSpanId() == "", "unexpected trace: %v", resp.Trace)
+	})
+}
+
+type traceCollector struct {
+	coltracepb.UnimplementedTraceServiceServer
+	mu    sync.Mutex
+	spans []*tracepb.Span
+}
+
+func (c *traceCollector) Export(
+	_ context.Context,
+	req *coltracepb.ExportTraceServiceRequest,
+) (*coltracepb.ExportTraceServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, rs := range req.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			c.spans = append(c.spans, ss.Spans...)
+		}
+	}
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+func (c *traceCollector) find(spanID string) *tracepb.Span {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, span := range c.spans {
+		if fmt.Sprintf("%x", span.SpanId) == spanID {
+			return span
+		}
+	}
+	return nil
+}
+
+func TestMain_AccessLog(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "access.log")
+
+	_, conn := setup(t, "--json", "--access-log.enable", "--access-log.file", logFile,
+		"--access-log.sample-rate", "0", "--access-log.metadata", "X-Custom",
+		"--access-log.field", "code", "--access-log.field", "request_size", "--access-log.field", "response_size",
+		"--access-log.field", "authority", "--access-log.field", "metadata", "--access-log.field", "trace_id")
+	defer conn.Close()
+	waitForServerUp(t, conn)
+
+	client := echopb.NewEchoServiceClient(conn)
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		"x-custom", "val", "traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	// successful calls are not sampled
+	_, err := client.Echo(ctx, &echopb.EchoRequest{Ping: "hello"})
+	assert(t, err == nil, "unexpected error: %v", err)
+
+	req := &echopb.EchoRequest{Ping: "hello", Status: &echopb.Status{Code: uint32(codes.NotFound)}}
+	_, err = client.Echo(ctx, req)
+	assert(t, status.Code(err) == codes.NotFound, "unexpected error: %v", err)
+
+	b, err := os.ReadFile(logFile)
+	assert(t, err == nil, "failed to read access log: %v", err)
+
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	assert(t, len(lines) == 1, "unexpected access log: %s", b)
+
+	var entry struct {
+		Level        string
+		Msg          string
+		Method       string
+		Duration     int64
+		Code         string
+		RequestSize  int    `json:"request_size"`
+		ResponseSize *int   `json:"response_size"`
+		Authority    string
+		UserAgent    string `json:"user_agent"`
+		Metadata     map[string]string
+		TraceID      string `json:"trace_id"`
+	}
+	err = json.Unmarshal([]byte(lines[0]), &entry)
+	assert(t, err == nil, "failed to unmarshal access log entry %s: %v", lines[0], err)
+
+	assert(t, entry.Level == "INFO" && entry.Msg == "access", "unexpected entry: %s", lines[0])
+	assert(t, entry.Method == "/grpc_echo.v1.EchoService/Echo", "unexpected method: %s", entry.Method)
+	assert(t, entry.Duration > 0, "unexpected duration: %d", entry.Duration)
+	assert(t, entry.Code == "NotFound", "unexpected code: %s", entry.Code)
+	assert(t, entry.RequestSize == proto.Size(req), "unexpected request size: %d", entry.RequestSize)
+	assert(t, entry.ResponseSize != nil && *entry.ResponseSize == 0, "unexpected response size: %v", entry.ResponseSize)
+	assert(t, strings.HasPrefix(entry.Authority, "localhost:"), "unexpected authority: %s", entry.Authority)
+	assert(t, entry.UserAgent == "", "user agent is not selected: %s", entry.UserAgent)
+	assert(t, entry.Metadata["x-custom"] == "val", "unexpected metadata: %v", entry.Metadata)
+	assert(t, entry.TraceID == "4bf92f3577b34da6a3ce929d0e0e4736", "unexpected trace id: %s", entry.TraceID)
+}
+
+// genCert generates a self-signed certificate for the given name, writes it
+// and its key to the given paths and returns the pool with the certificate.
+func genCert(tb testing.TB, name, certFile, keyFile string) *x509.CertPool {
+	tb.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	assert(tb, err == nil, "failed to generate key: %v", err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              []string{name},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(crand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	assert(tb, err == nil, "failed to create certificate: %v", err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert(tb, err == nil, "failed to marshal key: %v", err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	err = os.WriteFile(certFile, certPEM, 0o600)
+	assert(tb, err == nil, "failed to write certificate: %v", err)
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600)
+	assert(tb, err == nil, "failed to write key: %v", err)
+
+	pool := x509.NewCertPool()
+	assert(tb, pool.AppendCertsFromPEM(certPEM), "failed to add certificate to pool")
+	return pool
+}
+
 func assert(tb testing.TB, cond bool, format string, args ...any) {
 	tb.Helper()
 	if !cond {
@@ -86,8 +1191,18 @@ func assert(tb testing.TB, cond bool, format string, args ...any) {
 }
 
 func setup(tb testing.TB, flags ...string) (port int, conn *grpc.ClientConn) {
+	return setupWithCreds(tb, insecure.NewCredentials(), flags...)
+}
+
+func setupWithCreds(
+	tb testing.TB,
+	creds credentials.TransportCredentials,
+	flags ...string,
+) (port int, conn *grpc.ClientConn) {
 	port = 40000 + int(rand.Int31n(10000))
 	os.Args = append([]string{"test", "--addr", ":" + strconv.Itoa(port)}, flags...)
+	// flags without defaults keep values from the previous run
+	reflect.ValueOf(&opts).Elem().SetZero()
 
 	done := make(chan struct{})
 	go func() {
@@ -114,7 +1229,7 @@ func setup(tb testing.TB, flags ...string) (port int, conn *grpc.ClientConn) {
 	<-started
 	time.Sleep(time.Millisecond * 50) // do not start right away
 	conn, err := grpc.NewClient(fmt.Sprintf("localhost:%d", port),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
 		grpc.WithUserAgent("grpc-echo-test-ua"))
 	if err != nil {
 		tb.Fatalf("failed to create client: %v", err)
@@ -0,0 +1,145 @@
+package grpcx
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Access log fields, which can be selected in AccessLog.Fields.
+const (
+	AccessLogCode         = "code"
+	AccessLogRequestSize  = "request_size"
+	AccessLogResponseSize = "response_size"
+	AccessLogUserAgent    = "user_agent"
+	AccessLogAuthority    = "authority"
+	AccessLogRealIP       = "real_ip"
+	AccessLogMetadata     = "metadata"
+	AccessLogTraceID      = "trace_id"
+)
+
+// AccessLog logs every call at info level with the method, the duration
+// and the selected fields. Successful calls are sampled, failed ones
+// are always logged.
+type AccessLog struct {
+	Logger *slog.Logger
+	// Fields to log, see AccessLog* constants.
+	Fields []string
+	// MetadataKeys to log, if AccessLogMetadata field is selected.
+	MetadataKeys []string
+	// SampleRate is the fraction of successful calls to log, from 0 to 1.
+	SampleRate float64
+	// RealIP resolves the real IP address of the client.
+	RealIP RealIPResolver
+}
+
+// UnaryInterceptor logs unary calls.
+func (l AccessLog) UnaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp any, err error) {
+	now := time.Now()
+	resp, err = handler(ctx, req)
+	l.log(ctx, info.FullMethod, now, err, sizeOf(req), sizeOf(resp))
+	return resp, err
+}
+
+// StreamInterceptor logs streams, sizes are summed over all messages.
+func (l AccessLog) StreamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	now := time.Now()
+	cs := &countingStream{ServerStream: ss}
+	err := handler(srv, cs)
+	l.log(ss.Context(), info.FullMethod, now, err, int(cs.received.Load()), int(cs.sent.Load()))
+	return err
+}
+
+func (l AccessLog) log(ctx context.Context, method string, start time.Time, err error, reqSize, respSize int) {
+	//nolint:gosec // no need for cryptographically secure random for sampling
+	if err == nil && l.SampleRate < 1 && rand.Float64() >= l.SampleRate {
+		return
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.Duration("duration", time.Since(start)),
+	}
+
+	for _, field := range l.Fields {
+		switch field {
+		case AccessLogCode:
+			attrs = append(attrs, slog.String(field, status.Code(err).String()))
+		case AccessLogRequestSize:
+			attrs = append(attrs, slog.Int(field, reqSize))
+		case AccessLogResponseSize:
+			attrs = append(attrs, slog.Int(field, respSize))
+		case AccessLogUserAgent:
+			attrs = append(attrs, slog.String(field, strings.Join(md.Get("user-agent"), ",")))
+		case AccessLogAuthority:
+			attrs = append(attrs, slog.String(field, strings.Join(md.Get(":authority"), ",")))
+		case AccessLogRealIP:
+			ip, _ := l.RealIP.RealIP(ctx)
+			attrs = append(attrs, slog.String(field, ip))
+		case AccessLogMetadata:
+			mdAttrs := make([]any, 0, len(l.MetadataKeys))
+			for _, k := range l.MetadataKeys {
+				if vals := md.Get(k); len(vals) > 0 {
+					mdAttrs = append(mdAttrs, slog.String(strings.ToLower(k), strings.Join(vals, ",")))
+				}
+			}
+			attrs = append(attrs, slog.Group(field, mdAttrs...))
+		case AccessLogTraceID:
+			if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+				attrs = append(attrs, slog.String(field, sc.TraceID().String()))
+			}
+		}
+	}
+
+	l.Logger.LogAttrs(ctx, slog.LevelInfo, "access", attrs...)
+}
+
+func sizeOf(msg any) int {
+	if m, ok := msg.(proto.Message); ok {
+		return proto.Size(m)
+	}
+	return 0
+}
+
+// countingStream counts sizes of received and sent messages.
+type countingStream struct {
+	grpc.ServerStream
+	received atomic.Int64
+	sent     atomic.Int64
+}
+
+func (s *countingStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.received.Add(int64(sizeOf(m)))
+	return nil
+}
+
+func (s *countingStream) SendMsg(m any) error {
+	if err := s.ServerStream.SendMsg(m); err != nil {
+		return err
+	}
+	s.sent.Add(int64(sizeOf(m)))
+	return nil
+}
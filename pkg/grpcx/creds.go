@@ -0,0 +1,53 @@
+package grpcx
+
+import (
+	"net"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// PlaintextListener marks connections accepted from the listener as plaintext,
+// so that credentials made by PerListenerCredentials skip the handshake for them.
+func PlaintextListener(lis net.Listener) net.Listener { return plaintextListener{Listener: lis} }
+
+type plaintextListener struct{ net.Listener }
+
+func (l plaintextListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return plaintextConn{Conn: conn}, nil
+}
+
+type plaintextConn struct{ net.Conn }
+
+// PerListenerCredentials wraps the credentials to perform the handshake
+// only for connections, which are not accepted from a PlaintextListener,
+// so that one gRPC server is able to serve both plaintext and TLS listeners.
+// Connections, handshaked by DemuxListener, are passed as is.
+func PerListenerCredentials(creds credentials.TransportCredentials) credentials.TransportCredentials {
+	return perListenerCreds{TransportCredentials: creds}
+}
+
+type perListenerCreds struct {
+	credentials.TransportCredentials
+}
+
+func (c perListenerCreds) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	switch conn := conn.(type) {
+	case plaintextConn:
+		return insecure.NewCredentials().ServerHandshake(conn)
+	case tlsDemuxConn:
+		return conn, credentials.TLSInfo{
+			State:          conn.ConnectionState(),
+			CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity},
+		}, nil
+	}
+	return c.TransportCredentials.ServerHandshake(conn)
+}
+
+func (c perListenerCreds) Clone() credentials.TransportCredentials {
+	return perListenerCreds{TransportCredentials: c.TransportCredentials.Clone()}
+}
@@ -0,0 +1,321 @@
+package grpcx
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/hpack"
+)
+
+// demuxTimeout limits the time to complete TLS handshake
+// and to receive the first request of the connection.
+const demuxTimeout = 5 * time.Second
+
+// DemuxListener splits connections accepted from the listener between the
+// gRPC server and the HTTP server, so that native gRPC is served by the gRPC
+// transport with all of its connection-level settings, along with gRPC-Web,
+// Connect and REST served by the HTTP server on the same port.
+//
+// HTTP/2 connections with prior knowledge, which start with a gRPC request or
+// don't make any request in time, go to the gRPC listener, while HTTP/1.x
+// ones and HTTP/2 ones, which start with any other request, go to the HTTP
+// listener. The underlying listener is closed when both of them are closed.
+//
+// Connections, which are not accepted from a PlaintextListener, are
+// handshaked with the TLS config first, as the request can't be seen
+// otherwise, so the gRPC server skips the handshake for them, see
+// PerListenerCredentials, and the HTTP server must serve them as plaintext
+// ones, see DemuxConnContext.
+func DemuxListener(lis net.Listener, tlsCfg *tls.Config) (grpcLis, httpLis net.Listener) {
+	if tlsCfg != nil {
+		tlsCfg = tlsCfg.Clone()
+		tlsCfg.NextProtos = []string{"h2", "http/1.1"}
+	}
+
+	d := &demux{lis: lis, tlsCfg: tlsCfg, open: 2, done: make(chan struct{})}
+	d.grpc = &demuxListener{d: d, conns: make(chan net.Conn), closed: make(chan struct{})}
+	d.http = &demuxListener{d: d, conns: make(chan net.Conn), closed: make(chan struct{})}
+	go d.serve()
+	return d.grpc, d.http
+}
+
+type demux struct {
+	lis    net.Listener
+	tlsCfg *tls.Config
+
+	grpc, http *demuxListener
+
+	mu   sync.Mutex
+	open int // number of open listeners
+
+	done chan struct{} // closed when the underlying listener fails
+	err  error
+}
+
+func (d *demux) serve() {
+	var backoff time.Duration
+	for {
+		conn, err := d.lis.Accept()
+		if err != nil {
+			// retry temporary errors, e.g. reached limit of open files, as net/http does
+			if te, ok := err.(interface{ Temporary() bool }); ok && te.Temporary() {
+				backoff = min(max(2*backoff, 5*time.Millisecond), time.Second)
+				time.Sleep(backoff)
+				continue
+			}
+			d.err = err
+			close(d.done)
+			return
+		}
+		backoff = 0
+		go d.route(conn)
+	}
+}
+
+// route passes the connection to the listener of the server,
+// which is going to serve it.
+func (d *demux) route(conn net.Conn) {
+	_ = conn.SetDeadline(time.Now().Add(demuxTimeout))
+	res, isGRPC, err := d.sniff(conn)
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	l := d.http
+	if isGRPC {
+		l = d.grpc
+	}
+
+	select {
+	case l.conns <- res:
+	case <-l.closed:
+		_ = res.Close()
+	case <-d.done:
+		_ = res.Close()
+	}
+}
+
+// sniff reads the beginning of the connection to find out whether it's a gRPC
+// one and returns the connection, which replays the read data to the server.
+func (d *demux) sniff(raw net.Conn) (conn net.Conn, isGRPC bool, err error) {
+	var tlsConn *tls.Conn
+	conn = raw
+	if _, plaintext := raw.(plaintextConn); !plaintext && d.tlsCfg != nil {
+		tlsConn = tls.Server(raw, d.tlsCfg)
+		if err = tlsConn.Handshake(); err != nil {
+			return nil, false, err
+		}
+		conn = tlsConn
+	}
+
+	rec := &bytes.Buffer{}
+	isH2, err := readPreface(io.TeeReader(conn, rec))
+	if err != nil {
+		return nil, false, err
+	}
+
+	var rest io.Reader = conn
+	if isH2 {
+		var acked bool
+		if isGRPC, acked, err = readFirstRequest(conn, rec); err != nil {
+			return nil, false, err
+		}
+		if !isGRPC && !acked {
+			rest = &settingsAckFilter{r: conn}
+		}
+	}
+
+	dc := demuxConn{Conn: conn, r: io.MultiReader(rec, rest)}
+	if tlsConn != nil {
+		return tlsDemuxConn{demuxConn: dc, tls: tlsConn}, isGRPC, nil
+	}
+	if _, plaintext := raw.(plaintextConn); plaintext {
+		return plaintextConn{Conn: dc}, isGRPC, nil
+	}
+	return dc, isGRPC, nil
+}
+
+// readPreface reads from the connection until it either reads HTTP/2 client
+// preface or the data diverges from it.
+func readPreface(r io.Reader) (bool, error) {
+	preface := []byte(http2.ClientPreface)
+	buf := make([]byte, len(preface))
+	for n := 0; n < len(buf); {
+		m, err := r.Read(buf[n:])
+		n += m
+		if !bytes.Equal(buf[:n], preface[:n]) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// readFirstRequest sends empty settings, as clients may wait for them before
+// making requests, and reads frames until the headers of the first request
+// to check whether it's a gRPC one. Acknowledgement of the sent settings is
+// removed from the recorded frames, as the server doesn't expect it.
+func readFirstRequest(conn net.Conn, rec *bytes.Buffer) (isGRPC, acked bool, err error) {
+	if err = http2.NewFramer(conn, nil).WriteSettings(); err != nil {
+		return false, false, err
+	}
+
+	fr := http2.NewFramer(io.Discard, io.TeeReader(conn, rec))
+	fr.ReadMetaHeaders = hpack.NewDecoder(4096, nil)
+	for {
+		mark := rec.Len()
+		var f http2.Frame
+		f, err = fr.ReadFrame()
+		var ne net.Error
+		switch {
+		case errors.As(err, &ne) && ne.Timeout():
+			// idle connections are most likely opened by gRPC clients ahead of calls
+			return true, acked, nil
+		case errors.As(err, new(http2.ConnectionError)), errors.As(err, new(http2.StreamError)):
+			// let the HTTP server respond to the malformed request
+			return false, acked, nil
+		case err != nil:
+			return false, false, err
+		}
+
+		switch f := f.(type) {
+		case *http2.SettingsFrame:
+			if f.IsAck() && !acked {
+				rec.Truncate(mark)
+				acked = true
+			}
+		case *http2.MetaHeadersFrame:
+			for _, hf := range f.RegularFields() {
+				if hf.Name == "content-type" {
+					isGRPC = strings.HasPrefix(hf.Value, grpcContentType) && !strings.HasPrefix(hf.Value, grpcWebContentType)
+				}
+			}
+			return isGRPC, acked, nil
+		}
+	}
+}
+
+// settingsAckFilter drops the first settings acknowledgement from the stream
+// of HTTP/2 frames, if it's received after the first request.
+type settingsAckFilter struct {
+	r       io.Reader
+	buf     []byte
+	dropped bool
+}
+
+func (f *settingsAckFilter) Read(p []byte) (int, error) {
+	for len(f.buf) == 0 && !f.dropped {
+		frame := make([]byte, 9) // frame header
+		if _, err := io.ReadFull(f.r, frame); err != nil {
+			return 0, err
+		}
+
+		length := int(frame[0])<<16 | int(frame[1])<<8 | int(frame[2])
+		frame = append(frame, make([]byte, length)...)
+		if _, err := io.ReadFull(f.r, frame[9:]); err != nil {
+			return 0, err
+		}
+
+		if http2.FrameType(frame[3]) == http2.FrameSettings && http2.Flags(frame[4]).Has(http2.FlagSettingsAck) {
+			f.dropped = true
+			continue
+		}
+		f.buf = frame
+	}
+
+	if len(f.buf) > 0 {
+		n := copy(p, f.buf)
+		f.buf = f.buf[n:]
+		return n, nil
+	}
+	return f.r.Read(p)
+}
+
+// demuxConn replays the data read while sniffing the connection.
+type demuxConn struct {
+	net.Conn
+	r io.Reader
+}
+
+func (c demuxConn) Read(b []byte) (int, error) { return c.r.Read(b) }
+
+// tlsDemuxConn is a demultiplexed connection with the completed TLS handshake.
+type tlsDemuxConn struct {
+	demuxConn
+	tls *tls.Conn
+}
+
+func (c tlsDemuxConn) ConnectionState() tls.ConnectionState { return c.tls.ConnectionState() }
+
+// demuxListener accepts the connections routed to one of the servers.
+type demuxListener struct {
+	d      *demux
+	conns  chan net.Conn
+	once   sync.Once
+	closed chan struct{}
+}
+
+func (l *demuxListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	case <-l.d.done:
+		return nil, l.d.err
+	}
+}
+
+func (l *demuxListener) Close() (err error) {
+	l.once.Do(func() {
+		close(l.closed)
+
+		l.d.mu.Lock()
+		defer l.d.mu.Unlock()
+		if l.d.open--; l.d.open == 0 {
+			err = l.d.lis.Close()
+		}
+	})
+	return err
+}
+
+func (l *demuxListener) Addr() net.Addr { return l.d.lis.Addr() }
+
+type connTLSKey struct{}
+
+// DemuxConnContext stores the TLS state of the connection, demultiplexed by
+// DemuxListener, in the context, so that DemuxTLS sets it in the requests.
+// It's meant to be used as http.Server.ConnContext.
+func DemuxConnContext(ctx context.Context, c net.Conn) context.Context {
+	if tc, ok := c.(tlsDemuxConn); ok {
+		state := tc.ConnectionState()
+		return context.WithValue(ctx, connTLSKey{}, &state)
+	}
+	return ctx
+}
+
+// DemuxTLS sets the TLS state of the connection, stored by DemuxConnContext,
+// in the requests, as the HTTP server sees demultiplexed connections as
+// plaintext ones.
+func DemuxTLS(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if state, ok := r.Context().Value(connTLSKey{}).(*tls.ConnectionState); ok && r.TLS == nil {
+			r = r.WithContext(r.Context()) // shallow copy
+			r.TLS = state
+		}
+		next.ServeHTTP(w, r)
+	})
+}
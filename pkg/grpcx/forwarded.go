@@ -0,0 +1,101 @@
+package grpcx
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// ForwardedHop describes a hop of the forwarding chain.
+type ForwardedHop struct {
+	For   string // node, which made the request to the proxy
+	By    string // node of the proxy, which received the request
+	Proto string // protocol of the request, received by the proxy
+	Host  string // host of the request, received by the proxy
+	// Private is set if For is an address from a private network.
+	Private bool
+}
+
+// Forwarded returns the forwarding chain from the client to the nearest proxy.
+// It's taken from Forwarded header (RFC 7239), if it's present, otherwise
+// from X-Forwarded-For, X-Forwarded-Proto and X-Forwarded-Host headers, whose
+// values are matched by their position, as the edge proxy usually sets
+// the latter two only once.
+func Forwarded(md metadata.MD) []ForwardedHop {
+	if vals := md.Get("Forwarded"); len(vals) > 0 {
+		elems := parseForwarded(vals)
+		hops := make([]ForwardedHop, len(elems))
+		for i, elem := range elems {
+			hops[i] = makeHop(elem["for"], elem["by"], elem["proto"], elem["host"])
+		}
+		return hops
+	}
+
+	fors := splitList(md.Get("X-Forwarded-For"))
+	protos := splitList(md.Get("X-Forwarded-Proto"))
+	hosts := splitList(md.Get("X-Forwarded-Host"))
+
+	hops := make([]ForwardedHop, max(len(fors), len(protos), len(hosts)))
+	for i := range hops {
+		hops[i] = makeHop(at(fors, i), "", at(protos, i), at(hosts, i))
+	}
+	return hops
+}
+
+func makeHop(forNode, byNode, proto, host string) ForwardedHop {
+	hop := ForwardedHop{For: forNode, By: byNode, Proto: proto, Host: host}
+	if ip := parseNode(forNode); ip != nil {
+		hop.Private = isPrivateSubnet(ip)
+	}
+	return hop
+}
+
+func mustParseCIDR(s string) *net.IPNet {
+	_, ipnet, err := net.ParseCIDR(s)
+	if err != nil {
+		panic(fmt.Errorf("must parse cidr %q: %w", s, err))
+	}
+	return ipnet
+}
+
+var privateSubnets = []*net.IPNet{
+	mustParseCIDR("10.0.0.0/8"),
+	mustParseCIDR("100.64.0.0/10"),
+	mustParseCIDR("172.16.0.0/12"),
+	mustParseCIDR("192.0.0.0/24"),
+	mustParseCIDR("192.168.0.0/16"),
+	mustParseCIDR("198.18.0.0/15"),
+	mustParseCIDR("::1/128"),
+	mustParseCIDR("fc00::/7"),
+	mustParseCIDR("fe80::/10"),
+}
+
+func isPrivateSubnet(ip net.IP) bool {
+	for _, ipnet := range privateSubnets {
+		if ipnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitList splits the values of a comma-separated list header.
+func splitList(vals []string) (res []string) {
+	for _, v := range vals {
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				res = append(res, item)
+			}
+		}
+	}
+	return res
+}
+
+func at(ss []string, i int) string {
+	if i < len(ss) {
+		return ss[i]
+	}
+	return ""
+}
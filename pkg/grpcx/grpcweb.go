@@ -0,0 +1,170 @@
+package grpcx
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	grpcContentType        = "application/grpc"
+	grpcWebContentType     = "application/grpc-web"
+	grpcWebTextContentType = "application/grpc-web-text"
+)
+
+// GRPCWeb translates binary gRPC-Web requests into gRPC ones for the gRPC
+// server, so that all of its services and streaming methods are available,
+// and translates the responses back, sending trailers in the last frame
+// of the body, as gRPC-Web requires.
+func GRPCWeb(srv http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType := r.Header.Get("Content-Type")
+
+		r = r.Clone(WithGRPCTimeout(r.Context(), r.Header.Get("Grpc-Timeout")))
+		r.Header.Set("Content-Type", grpcContentType+strings.TrimPrefix(contentType, grpcWebContentType))
+		r.Header.Del("Content-Length")
+		// gRPC server handles only HTTP/2 requests, while the framing is the same for any version
+		r.Proto, r.ProtoMajor, r.ProtoMinor = "HTTP/2.0", 2, 0
+
+		ww := &webResponseWriter{w: w, header: http.Header{}}
+		srv.ServeHTTP(ww, r)
+		ww.writeTrailers()
+	})
+}
+
+// webResponseWriter writes gRPC response as gRPC-Web one. The headers are
+// kept aside until the first write, as gRPC server keeps setting the
+// trailers in them after the headers are sent.
+type webResponseWriter struct {
+	w           http.ResponseWriter
+	header      http.Header
+	wroteHeader bool
+}
+
+func (w *webResponseWriter) Header() http.Header { return w.header }
+
+func (w *webResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	h := w.w.Header()
+	for k, vals := range w.header {
+		if k == "Trailer" || strings.HasPrefix(k, http.TrailerPrefix) {
+			continue
+		}
+		h[k] = vals
+	}
+	if ct := h.Get("Content-Type"); strings.HasPrefix(ct, grpcContentType) {
+		h.Set("Content-Type", grpcWebContentType+strings.TrimPrefix(ct, grpcContentType))
+	}
+	w.w.WriteHeader(code)
+}
+
+func (w *webResponseWriter) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.w.Write(b)
+}
+
+func (w *webResponseWriter) Flush() {
+	w.WriteHeader(http.StatusOK)
+	if f, ok := w.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// writeTrailers writes the declared and the undeclared trailers,
+// set by gRPC server, in the trailer frame.
+func (w *webResponseWriter) writeTrailers() {
+	trailers := http.Header{}
+	for _, k := range w.header.Values("Trailer") {
+		if vals := w.header.Values(k); len(vals) > 0 {
+			trailers[http.CanonicalHeaderKey(k)] = vals
+		}
+	}
+	for k, vals := range w.header {
+		if name, ok := strings.CutPrefix(k, http.TrailerPrefix); ok {
+			trailers[http.CanonicalHeaderKey(name)] = vals
+		}
+	}
+
+	var buf bytes.Buffer
+	for k, vals := range trailers {
+		for _, v := range vals {
+			_, _ = fmt.Fprintf(&buf, "%s: %s\r\n", strings.ToLower(k), v)
+		}
+	}
+
+	frame := make([]byte, 5, 5+buf.Len())
+	// the trailer frame is flagged with the most significant bit
+	frame[0] = 0x80
+	binary.BigEndian.PutUint32(frame[1:], uint32(buf.Len())) //nolint:gosec // trailers don't exceed 4GB
+	_, _ = w.Write(append(frame, buf.Bytes()...))
+	w.Flush()
+}
+
+// GRPCWebText translates gRPC-Web text (base64) requests into binary
+// gRPC-Web ones for the next handler, which supports only the latter,
+// and encodes its responses back. Other requests are passed as is.
+func GRPCWebText(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType := r.Header.Get("Content-Type")
+		if !strings.HasPrefix(contentType, grpcWebTextContentType) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		r = r.Clone(r.Context())
+		r.Header.Set("Content-Type", grpcWebContentType+strings.TrimPrefix(contentType, grpcWebTextContentType))
+		r.Header.Del("Content-Length")
+		r.ContentLength = -1
+		r.Body = struct {
+			io.Reader
+			io.Closer
+		}{base64.NewDecoder(base64.StdEncoding, r.Body), r.Body}
+
+		next.ServeHTTP(&webTextResponseWriter{ResponseWriter: w}, r)
+	})
+}
+
+// webTextResponseWriter encodes each written chunk in base64 separately,
+// as gRPC-Web clients decode the response chunk by chunk.
+type webTextResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *webTextResponseWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		h := w.Header()
+		if ct := h.Get("Content-Type"); strings.HasPrefix(ct, grpcWebContentType) {
+			h.Set("Content-Type", grpcWebTextContentType+strings.TrimPrefix(ct, grpcWebContentType))
+		}
+		h.Del("Content-Length")
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *webTextResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if _, err := w.ResponseWriter.Write([]byte(base64.StdEncoding.EncodeToString(b))); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (w *webTextResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *webTextResponseWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
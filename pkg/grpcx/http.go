@@ -0,0 +1,141 @@
+package grpcx
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// HTTPContext returns the context of the HTTP request with the peer
+// and the incoming metadata set in the same way as grpc.Server.ServeHTTP
+// does it, so that gRPC handlers and interceptors called outside of the
+// gRPC server, e.g. for Connect or REST requests, see the same information
+// and behave in the same way as in the gRPC server. Such handlers expect
+// the request context to be made by it.
+// The grpc-timeout header is kept as is, see GRPCTimeout.
+func HTTPContext(r *http.Request) context.Context {
+	md := metadata.MD{}
+	if r.Host != "" {
+		md.Set(":authority", r.Host)
+	}
+
+	for k, vals := range r.Header {
+		k = strings.ToLower(k)
+		if isReservedHeader(k) {
+			continue
+		}
+		for _, v := range vals {
+			if strings.HasSuffix(k, "-bin") {
+				b, err := decodeBinHeader(v)
+				if err != nil {
+					continue
+				}
+				v = string(b)
+			}
+			md.Append(k, v)
+		}
+	}
+
+	p := &peer.Peer{Addr: httpAddr(r.RemoteAddr)}
+	if r.TLS != nil {
+		p.AuthInfo = credentials.TLSInfo{
+			State:          *r.TLS,
+			CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity},
+		}
+	}
+
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+	ctx = WithGRPCTimeout(ctx, r.Header.Get("Grpc-Timeout"))
+	return peer.NewContext(ctx, p)
+}
+
+// isReservedHeader checks whether the header is used by the transport
+// and thus is not passed to the handler as metadata.
+func isReservedHeader(k string) bool {
+	switch k {
+	case "grpc-encoding", "grpc-accept-encoding", "grpc-message", "grpc-status",
+		"grpc-timeout", "grpc-status-details-bin", "te", "connection":
+		return true
+	default:
+		return false
+	}
+}
+
+func decodeBinHeader(v string) ([]byte, error) {
+	if len(v)%4 == 0 {
+		// input was padded, or padding was not necessary
+		return base64.StdEncoding.DecodeString(v)
+	}
+	return base64.RawStdEncoding.DecodeString(v)
+}
+
+// httpAddr implements net.Addr for the remote address of the HTTP request.
+type httpAddr string
+
+func (a httpAddr) Network() string { return "tcp" }
+func (a httpAddr) String() string  { return string(a) }
+
+// MetadataStream implements grpc.ServerTransportStream to collect headers
+// and trailers, set by the handler, when it's called outside of gRPC server.
+type MetadataStream struct {
+	FullMethod string
+
+	mu      sync.Mutex
+	header  metadata.MD
+	trailer metadata.MD
+}
+
+var _ grpc.ServerTransportStream = (*MetadataStream)(nil)
+
+func (s *MetadataStream) Method() string { return s.FullMethod }
+
+func (s *MetadataStream) SetHeader(md metadata.MD) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *MetadataStream) SendHeader(md metadata.MD) error { return s.SetHeader(md) }
+
+func (s *MetadataStream) SetTrailer(md metadata.MD) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+// Header returns the collected headers.
+func (s *MetadataStream) Header() metadata.MD {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.header.Copy()
+}
+
+// Trailer returns the collected trailers.
+func (s *MetadataStream) Trailer() metadata.MD {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.trailer.Copy()
+}
+
+// ChainUnaryInterceptors chains the interceptors into one, the first one
+// is the outermost, as grpc.ChainUnaryInterceptor does it.
+func ChainUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			next, interceptor := handler, interceptors[i]
+			handler = func(ctx context.Context, req any) (any, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
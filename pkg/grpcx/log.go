@@ -8,8 +8,10 @@ import (
 	"context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/peer"
-	"log"
 	"time"
+	"fmt"
+	"runtime"
+	"strings"
 )
 
 // LogUnaryInterceptor logs gRPC calls.
@@ -54,48 +56,76 @@ func LogStreamInterceptor(
 	return err
 }
 
-// Logger implements grpclog.LoggerV2 to log to slog.
+// LevelFatal is the level of grpclog fatal messages, as slog has no such level.
+const LevelFatal = slog.LevelError + 4
+
+// Logger implements grpclog.LoggerV2 and grpclog.DepthLoggerV2 on top of
+// slog.Handler, emitting each grpclog severity at the matching slog level.
+// Messages are logged with "system" attribute set to "grpc" and the
+// "component" one, if the message is prefixed with it, as grpc-go does
+// for its internal components.
 type Logger struct {
-	Logger *log.Logger
+	Handler slog.Handler
+	// MinLevel drops messages of lower severities.
+	MinLevel slog.Level
+	// Verbosity is the grpclog verbosity level, V(l) reports false
+	// for l greater than it.
+	Verbosity int
 }
 
-func (l Logger) Info(args ...any)    { l.log("[INFO]", args...) }
-func (l Logger) Warning(args ...any) { l.log("[WARN]", args...) }
-func (l Logger) Error(args ...any)   { l.log("[ERROR]", args...) }
-func (l Logger) Fatal(args ...any)   { l.log("[FATAL]", args...); os.Exit(1) }
+func (l Logger) Info(args ...any)    { l.log(0, slog.LevelInfo, fmt.Sprint(args...)) }
+func (l Logger) Warning(args ...any) { l.log(0, slog.LevelWarn, fmt.Sprint(args...)) }
+func (l Logger) Error(args ...any)   { l.log(0, slog.LevelError, fmt.Sprint(args...)) }
+func (l Logger) Fatal(args ...any)   { l.log(0, LevelFatal, fmt.Sprint(args...)); os.Exit(1) }
 
-func (l Logger) Infoln(args ...any)    { l.logln("[INFO]", args...) }
-func (l Logger) Warningln(args ...any) { l.logln("[WARN]", args...) }
-func (l Logger) Errorln(args ...any)   { l.logln("[ERROR]", args...) }
-func (l Logger) Fatalln(args ...any)   { l.logln("[FATAL]", args...); os.Exit(1) }
+func (l Logger) Infoln(args ...any)    { l.log(0, slog.LevelInfo, sprintln(args...)) }
+func (l Logger) Warningln(args ...any) { l.log(0, slog.LevelWarn, sprintln(args...)) }
+func (l Logger) Errorln(args ...any)   { l.log(0, slog.LevelError, sprintln(args...)) }
+func (l Logger) Fatalln(args ...any)   { l.log(0, LevelFatal, sprintln(args...)); os.Exit(1) }
 
-func (l Logger) Infof(format string, args ...any)    { l.logf("[INFO] "+format, args...) }
-func (l Logger) Warningf(format string, args ...any) { l.logf("[WARN] "+format, args...) }
-func (l Logger) Errorf(format string, args ...any)   { l.logf("[ERROR] "+format, args...) }
-func (l Logger) Fatalf(format string, args ...any)   { l.logf("[FATAL] "+format, args...); os.Exit(1) }
+func (l Logger) Infof(format string, args ...any)    { l.logf(0, slog.LevelInfo, format, args...) }
+func (l Logger) Warningf(format string, args ...any) { l.logf(0, slog.LevelWarn, format, args...) }
+func (l Logger) Errorf(format string, args ...any)   { l.logf(0, slog.LevelError, format, args...) }
+func (l Logger) Fatalf(format string, args ...any)   { l.logf(0, LevelFatal, format, args...); os.Exit(1) }
 
-func (l Logger) log(lvl string, args ...any) {
-	l.Logger.Print(append([]any{"[grpclog]", lvl}, args...)...)
-}
+func (l Logger) InfoDepth(depth int, args ...any)    { l.log(depth+1, slog.LevelInfo, sprintln(args...)) }
+func (l Logger) WarningDepth(depth int, args ...any) { l.log(depth+1, slog.LevelWarn, sprintln(args...)) }
+func (l Logger) ErrorDepth(depth int, args ...any)   { l.log(depth+1, slog.LevelError, sprintln(args...)) }
+func (l Logger) FatalDepth(depth int, args ...any)   { l.log(depth+1, LevelFatal, sprintln(args...)); os.Exit(1) }
 
-func (l Logger) logln(lvl string, args ...any) {
-	l.Logger.Println(append([]any{"[grpclog]", lvl}, args...)...)
-}
+// V reports whether the verbosity level l is enabled.
+func (l Logger) V(lvl int) bool { return lvl <= l.Verbosity }
 
-func (l Logger) logf(format string, args ...any) {
-	l.Logger.Printf("[grpclog] "+format, args...)
-}
+// log emits the message with the source of the caller, depth frames
+// above the caller of the Logger method. Depth methods are called by
+// grpclog package functions, which already count themselves in depth,
+// hence they add one more frame.
+func (l Logger) log(depth int, lvl slog.Level, msg string) {
+	ctx := context.Background()
+	if lvl < l.MinLevel || !l.Handler.Enabled(ctx, lvl) {
+		return
+	}
 
-func (Logger) V(l int) bool {
-	lvl := slog.Level(-1)
-	switch l {
-	case 0:
-		lvl = slog.LevelInfo
-	case 1:
-		lvl = slog.LevelWarn
-	case 2, 3:
-		lvl = slog.LevelError
+	var pcs [1]uintptr
+	runtime.Callers(depth+3, pcs[:]) // skip runtime.Callers, log and the Logger method
+	rec := slog.NewRecord(time.Now(), lvl, msg, pcs[0])
+	rec.AddAttrs(slog.String("system", "grpc"))
+
+	// grpc-go prefixes messages of its components with their names, e.g. "[core] ..."
+	if rest, ok := strings.CutPrefix(msg, "["); ok {
+		if component, text, ok := strings.Cut(rest, "] "); ok && !strings.ContainsAny(component, " []") {
+			rec.Message = text
+			rec.AddAttrs(slog.String("component", component))
+		}
 	}
 
-	return slog.Default().Enabled(context.Background(), lvl)
+	_ = l.Handler.Handle(ctx, rec)
+}
+
+func (l Logger) logf(depth int, lvl slog.Level, format string, args ...any) {
+	l.log(depth+1, lvl, fmt.Sprintf(format, args...))
+}
+
+func sprintln(args ...any) string {
+	return strings.TrimSuffix(fmt.Sprintln(args...), "\n")
 }
@@ -0,0 +1,134 @@
+package grpcx
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"runtime"
+	"testing"
+
+	"google.golang.org/grpc/grpclog"
+)
+
+func TestLogger(t *testing.T) {
+	t.Run("levels", func(t *testing.T) {
+		h := &capturingHandler{}
+		l := Logger{Handler: h}
+
+		l.Info("info")
+		l.Warning("warning")
+		l.Errorf("error %d", 1)
+
+		want := []struct {
+			lvl slog.Level
+			msg string
+		}{
+			{slog.LevelInfo, "info"},
+			{slog.LevelWarn, "warning"},
+			{slog.LevelError, "error 1"},
+		}
+		if len(h.records) != len(want) {
+			t.Fatalf("unexpected number of records: %d, want %d", len(h.records), len(want))
+		}
+		for i, w := range want {
+			if h.records[i].Level != w.lvl || h.records[i].Message != w.msg {
+				t.Errorf("unexpected record %d: %s %q, want %s %q",
+					i, h.records[i].Level, h.records[i].Message, w.lvl, w.msg)
+			}
+			if sys := attr(h.records[i], "system"); sys != "grpc" {
+				t.Errorf("unexpected system attribute of record %d: %q", i, sys)
+			}
+		}
+	})
+
+	t.Run("min level", func(t *testing.T) {
+		h := &capturingHandler{}
+		l := Logger{Handler: h, MinLevel: slog.LevelWarn}
+
+		l.Info("dropped")
+		l.Infoln("dropped")
+		l.Warningln("kept")
+
+		if len(h.records) != 1 || h.records[0].Message != "kept" {
+			t.Fatalf("unexpected records: %+v", h.records)
+		}
+	})
+
+	t.Run("verbosity", func(t *testing.T) {
+		if (Logger{Verbosity: 1}).V(2) {
+			t.Errorf("V(2) must be false for verbosity 1")
+		}
+		if !(Logger{Verbosity: 2}).V(2) {
+			t.Errorf("V(2) must be true for verbosity 2")
+		}
+		if !(Logger{}).V(0) {
+			t.Errorf("V(0) must be true for zero verbosity")
+		}
+	})
+
+	t.Run("component", func(t *testing.T) {
+		h := &capturingHandler{}
+		l := Logger{Handler: h}
+
+		l.Info("[core] msg")
+		l.Info("[not a component]msg")
+
+		if len(h.records) != 2 {
+			t.Fatalf("unexpected number of records: %d", len(h.records))
+		}
+		if rec := h.records[0]; rec.Message != "msg" || attr(rec, "component") != "core" {
+			t.Errorf("unexpected record: %q, component %q", rec.Message, attr(rec, "component"))
+		}
+		if rec := h.records[1]; rec.Message != "[not a component]msg" || attr(rec, "component") != "" {
+			t.Errorf("unexpected record: %q, component %q", rec.Message, attr(rec, "component"))
+		}
+	})
+
+	t.Run("depth", func(t *testing.T) {
+		h := &capturingHandler{}
+		grpclog.SetLoggerV2(Logger{Handler: h})
+		defer grpclog.SetLoggerV2(grpclog.NewLoggerV2(io.Discard, io.Discard, io.Discard))
+
+		_, file, line, _ := runtime.Caller(0)
+		grpclog.InfoDepth(0, "depth")
+		grpclog.Component("core").Warning("component")
+
+		if len(h.records) != 2 {
+			t.Fatalf("unexpected number of records: %d", len(h.records))
+		}
+		if rec := h.records[1]; rec.Message != "component" || attr(rec, "component") != "core" {
+			t.Errorf("unexpected record: %q, component %q", rec.Message, attr(rec, "component"))
+		}
+		for i, rec := range h.records {
+			frame, _ := runtime.CallersFrames([]uintptr{rec.PC}).Next()
+			if frame.File != file || frame.Line != line+1+i {
+				t.Errorf("unexpected source of record %d: %s:%d, want %s:%d", i, frame.File, frame.Line, file, line+1+i)
+			}
+		}
+	})
+}
+
+// capturingHandler collects the handled records.
+type capturingHandler struct {
+	records []slog.Record
+}
+
+func (h *capturingHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (h *capturingHandler) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h *capturingHandler) WithGroup(string) slog.Handler            { return h }
+
+func (h *capturingHandler) Handle(_ context.Context, rec slog.Record) error {
+	h.records = append(h.records, rec)
+	return nil
+}
+
+func attr(rec slog.Record, key string) (val string) {
+	rec.Attrs(func(a slog.Attr) bool {
+		if a.Key == key {
+			val = a.Value.String()
+			return false
+		}
+		return true
+	})
+	return val
+}
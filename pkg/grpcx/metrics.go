@@ -0,0 +1,140 @@
+package grpcx
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics collects statistics of gRPC calls and connections for Prometheus.
+type Metrics struct {
+	started  *prometheus.CounterVec
+	handled  *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	inFlight *prometheus.GaugeVec
+
+	openConns     *prometheus.GaugeVec
+	acceptedConns *prometheus.CounterVec
+}
+
+// NewMetrics makes a new Metrics and registers its collectors.
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		started: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_started_total",
+			Help: "Total number of RPCs started on the server.",
+		}, []string{"method", "type"}),
+		handled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_handled_total",
+			Help: "Total number of RPCs completed on the server, regardless of success or failure.",
+		}, []string{"method", "type", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_server_handling_seconds",
+			Help:    "Duration of RPCs handled by the server.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "type"}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "grpc_server_streams_in_flight",
+			Help: "Number of streams being currently handled by the server.",
+		}, []string{"method", "type"}),
+		openConns: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "grpc_server_open_connections",
+			Help: "Number of currently open connections.",
+		}, []string{"listener"}),
+		acceptedConns: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_accepted_connections_total",
+			Help: "Total number of accepted connections.",
+		}, []string{"listener"}),
+	}
+
+	reg.MustRegister(m.started, m.handled, m.duration, m.inFlight, m.openConns, m.acceptedConns)
+	return m
+}
+
+// UnaryInterceptor collects statistics of unary calls.
+func (m *Metrics) UnaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp any, err error) {
+	done := m.start(info.FullMethod, "unary")
+	resp, err = handler(ctx, req)
+	done(err)
+	return resp, err
+}
+
+// StreamInterceptor collects statistics of streams.
+func (m *Metrics) StreamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	typ := "bidi_stream"
+	switch {
+	case !info.IsClientStream:
+		typ = "server_stream"
+	case !info.IsServerStream:
+		typ = "client_stream"
+	}
+
+	m.inFlight.WithLabelValues(info.FullMethod, typ).Inc()
+	defer m.inFlight.WithLabelValues(info.FullMethod, typ).Dec()
+
+	done := m.start(info.FullMethod, typ)
+	err := handler(srv, ss)
+	done(err)
+	return err
+}
+
+func (m *Metrics) start(method, typ string) (done func(error)) {
+	m.started.WithLabelValues(method, typ).Inc()
+	now := time.Now()
+	return func(err error) {
+		m.handled.WithLabelValues(method, typ, status.Code(err).String()).Inc()
+		m.duration.WithLabelValues(method, typ).Observe(time.Since(now).Seconds())
+	}
+}
+
+// Listener wraps the listener to count its connections.
+func (m *Metrics) Listener(lis net.Listener, name string) net.Listener {
+	return metricsListener{
+		Listener: lis,
+		open:     m.openConns.WithLabelValues(name),
+		accepted: m.acceptedConns.WithLabelValues(name),
+	}
+}
+
+type metricsListener struct {
+	net.Listener
+	open     prometheus.Gauge
+	accepted prometheus.Counter
+}
+
+func (l metricsListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	l.accepted.Inc()
+	l.open.Inc()
+	return &metricsConn{Conn: conn, open: l.open}, nil
+}
+
+type metricsConn struct {
+	net.Conn
+	open prometheus.Gauge
+	once sync.Once
+}
+
+func (c *metricsConn) Close() error {
+	c.once.Do(c.open.Dec)
+	return c.Conn.Close()
+}
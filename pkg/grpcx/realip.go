@@ -7,73 +7,149 @@ import (
 	"net"
 	"strings"
 	"fmt"
+	"slices"
 )
 
+// RealIPResolver resolves the real IP address of the client. Headers are
+// taken into account only if the request came from a trusted proxy, then
+// the hops from the first present header are walked from right to left,
+// until the first untrusted one. Zero value trusts no one, thus responds
+// with the peer address.
+type RealIPResolver struct {
+	// TrustedProxies are the networks of proxies, which are allowed to set headers.
+	TrustedProxies []*net.IPNet
+	// Headers to look up for the client address, in order of precedence.
+	// "Forwarded" header is parsed as described in RFC 7239, the others
+	// are treated as comma-separated lists of addresses, as X-Forwarded-For.
+	Headers []string
+}
+
 // RealIP extracts the real IP address from the context.
-func RealIP(ctx context.Context) (string, error) {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		md = metadata.New(nil)
+func (r RealIPResolver) RealIP(ctx context.Context) (string, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "", fmt.Errorf("no valid ip found")
+	}
+
+	ip := parseNode(p.Addr.String())
+	if ip == nil {
+		return "", fmt.Errorf("can't parse ip %q", p.Addr.String())
 	}
 
-	var firstIP string
-	for _, h := range []string{"X-Forwarded-For", "X-Real-Ip"} {
-		addresses := md.Get(h)
-		for i := len(addresses) - 1; i >= 0; i-- {
-			ip := strings.TrimSpace(addresses[i])
-			realIP := net.ParseIP(ip)
-			if firstIP == "" && realIP != nil {
-				firstIP = ip
+	if !r.trusted(ip) {
+		return ip.String(), nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, h := range r.Headers {
+		hops := headerHops(md, h)
+		if len(hops) == 0 {
+			continue
+		}
+
+		for i := len(hops) - 1; i >= 0; i-- {
+			hopIP := parseNode(hops[i])
+			if hopIP == nil {
+				// the chain is broken, the nearest valid hop is the best guess
+				break
 			}
-			if !realIP.IsGlobalUnicast() || isPrivateSubnet(realIP) {
-				continue
+			if ip = hopIP; !r.trusted(ip) {
+				break
 			}
-			return ip, nil
 		}
-	}
 
-	if firstIP != "" {
-		return firstIP, nil
+		return ip.String(), nil
 	}
 
-	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
-		ip, _, err := net.SplitHostPort(p.Addr.String())
-		if err != nil {
-			return "", fmt.Errorf("can't parse ip %q: %w", p.Addr.String(), err)
-		}
-		if netIP := net.ParseIP(ip); netIP != nil {
-			return ip, nil
+	return ip.String(), nil
+}
+
+func (r RealIPResolver) trusted(ip net.IP) bool {
+	return slices.ContainsFunc(r.TrustedProxies, func(n *net.IPNet) bool { return n.Contains(ip) })
+}
+
+// headerHops returns the addresses listed in the header, from the client
+// to the nearest proxy.
+func headerHops(md metadata.MD, header string) (hops []string) {
+	vals := md.Get(header)
+	if strings.EqualFold(header, "Forwarded") {
+		for _, elem := range parseForwarded(vals) {
+			hops = append(hops, elem["for"])
 		}
+		return hops
 	}
 
-	return "", fmt.Errorf("no valid ip found")
+	return splitList(vals)
 }
 
-func mustParseCIDR(s string) *net.IPNet {
-	_, ipnet, err := net.ParseCIDR(s)
+// parseNode parses the IP address from the node, which may be
+// an IP address with or without a port, and an IPv6 one in brackets.
+// It returns nil for unknown and obfuscated nodes.
+func parseNode(s string) net.IP {
+	s = strings.TrimSpace(s)
+	if ip := net.ParseIP(s); ip != nil {
+		return ip
+	}
+
+	host, _, err := net.SplitHostPort(s)
 	if err != nil {
-		panic(fmt.Errorf("must parse cidr %q: %w", s, err))
+		host = strings.TrimSuffix(strings.TrimPrefix(s, "["), "]")
+	}
+	return net.ParseIP(host)
+}
+
+// parseForwarded parses the values of Forwarded header, as described
+// in RFC 7239, into elements, which are sets of parameters with
+// lowercased names and unquoted values.
+func parseForwarded(vals []string) (elems []map[string]string) {
+	for _, v := range vals {
+		for _, elem := range splitQuoted(v, ',') {
+			params := map[string]string{}
+			for _, pair := range splitQuoted(elem, ';') {
+				key, val, ok := strings.Cut(pair, "=")
+				if !ok {
+					continue
+				}
+				params[strings.ToLower(strings.TrimSpace(key))] = unquote(strings.TrimSpace(val))
+			}
+			elems = append(elems, params)
+		}
 	}
-	return ipnet
+	return elems
 }
 
-var privateSubnets = []*net.IPNet{
-	mustParseCIDR("10.0.0.0/8"),
-	mustParseCIDR("100.64.0.0/10"),
-	mustParseCIDR("172.16.0.0/12"),
-	mustParseCIDR("192.0.0.0/24"),
-	mustParseCIDR("192.168.0.0/16"),
-	mustParseCIDR("198.18.0.0/15"),
-	mustParseCIDR("::1/128"),
-	mustParseCIDR("fc00::/7"),
-	mustParseCIDR("fe80::/10"),
+// splitQuoted splits the string by the separator, outside of quoted strings.
+func splitQuoted(s string, sep byte) (res []string) {
+	var quoted, escaped bool
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && s[i] == '\\':
+			escaped = true
+		case s[i] == '"':
+			quoted = !quoted
+		case !quoted && s[i] == sep:
+			res = append(res, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(res, s[start:])
 }
 
-func isPrivateSubnet(ip net.IP) bool {
-	for _, ipnet := range privateSubnets {
-		if ipnet.Contains(ip) {
-			return true
+func unquote(s string) string {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return s
+	}
+
+	var sb strings.Builder
+	s = s[1 : len(s)-1]
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
 		}
+		sb.WriteByte(s[i])
 	}
-	return false
+	return sb.String()
 }
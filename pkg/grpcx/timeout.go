@@ -6,6 +6,8 @@ import (
 	"context"
 	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/codes"
+	"strconv"
+	"google.golang.org/grpc/tap"
 )
 
 // TimeoutStreamInterceptor returns a new unary server interceptor for timeout.
@@ -27,7 +29,9 @@ func TimeoutStreamInterceptor(timeout time.Duration) grpc.StreamServerIntercepto
 		_ *grpc.StreamServerInfo,
 		handler grpc.StreamHandler,
 	) (err error) {
-		ctx, cancel := context.WithTimeout(ss.Context(), timeout)
+		deadline, ok := ss.Context().Deadline()
+		ctx := context.WithValue(ss.Context(), clientDeadlineKey{}, clientDeadline{deadline: deadline, ok: ok})
+		ctx, cancel := context.WithTimeout(ctx, timeout)
 		defer cancel()
 
 		done := make(chan error, 1)
@@ -44,9 +48,92 @@ func TimeoutStreamInterceptor(timeout time.Duration) grpc.StreamServerIntercepto
 	}
 }
 
+type clientDeadlineKey struct{}
+
+type clientDeadline struct {
+	deadline time.Time
+	ok       bool
+}
+
+// ClientDeadline returns the deadline set by the client, if any. It differs
+// from the context deadline for streams, as the server applies its own timeout.
+func ClientDeadline(ctx context.Context) (time.Time, bool) {
+	if d, ok := ctx.Value(clientDeadlineKey{}).(clientDeadline); ok {
+		return d.deadline, d.ok
+	}
+	return ctx.Deadline()
+}
+
+type grpcTimeoutKey struct{}
+
+// WithGRPCTimeout returns the context with the grpc-timeout header value
+// of the call, as it was received.
+func WithGRPCTimeout(ctx context.Context, timeout string) context.Context {
+	if timeout == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, grpcTimeoutKey{}, timeout)
+}
+
+// GRPCTimeout returns the grpc-timeout header value of the call, if any.
+func GRPCTimeout(ctx context.Context) string {
+	timeout, _ := ctx.Value(grpcTimeoutKey{}).(string)
+	return timeout
+}
+
+// GRPCTimeoutTap stores the grpc-timeout header of native gRPC calls in the
+// context. grpc-go consumes the header in the transport without exposing it,
+// so the value is restored from the deadline in the same format, right after
+// the transport has parsed the header, before any interceptor is called.
+func GRPCTimeoutTap(ctx context.Context, _ *tap.Info) (context.Context, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		ctx = WithGRPCTimeout(ctx, EncodeTimeout(time.Until(deadline)))
+	}
+	return ctx, nil
+}
+
 type contextedStream struct {
 	ctx context.Context
 	grpc.ServerStream
 }
 
 func (s contextedStream) Context() context.Context { return s.ctx }
+
+// maxTimeoutValue is the largest value allowed in the grpc-timeout header, which fits into 8 digits.
+const maxTimeoutValue int64 = 100000000 - 1
+
+// EncodeTimeout encodes the duration in the grpc-timeout header format,
+// in the same way as grpc-go does it, i.e. with the most precise unit,
+// which fits into 8 digits, rounded up.
+func EncodeTimeout(t time.Duration) string {
+	if t <= 0 {
+		return "0n"
+	}
+
+	units := []struct {
+		unit time.Duration
+		sfx  string
+	}{
+		{time.Nanosecond, "n"},
+		{time.Microsecond, "u"},
+		{time.Millisecond, "m"},
+		{time.Second, "S"},
+		{time.Minute, "M"},
+	}
+
+	for _, u := range units {
+		if d := divCeil(t, u.unit); d <= maxTimeoutValue {
+			return strconv.FormatInt(d, 10) + u.sfx
+		}
+	}
+
+	// maxTimeoutValue hours exceeds time.Duration range anyway
+	return strconv.FormatInt(divCeil(t, time.Hour), 10) + "H"
+}
+
+func divCeil(d, r time.Duration) int64 {
+	if d%r > 0 {
+		return int64(d/r + 1)
+	}
+	return int64(d / r)
+}
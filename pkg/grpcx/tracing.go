@@ -0,0 +1,106 @@
+package grpcx
+
+import (
+	"context"
+	"strings"
+
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Tracing creates server spans for calls, continuing the trace
+// propagated by the client in the incoming metadata.
+type Tracing struct {
+	Tracer     trace.Tracer
+	Propagator propagation.TextMapPropagator
+}
+
+// UnaryInterceptor traces unary calls.
+func (t Tracing) UnaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp any, err error) {
+	ctx, span := t.start(ctx, info.FullMethod)
+	resp, err = handler(ctx, req)
+	endSpan(span, err)
+	return resp, err
+}
+
+// StreamInterceptor traces streams.
+func (t Tracing) StreamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, span := t.start(ss.Context(), info.FullMethod)
+	err := handler(srv, contextedStream{ctx, ss})
+	endSpan(span, err)
+	return err
+}
+
+type parentSpanKey struct{}
+
+// ParentSpanContext returns the span context propagated by the client, if any.
+func ParentSpanContext(ctx context.Context) trace.SpanContext {
+	sc, _ := ctx.Value(parentSpanKey{}).(trace.SpanContext)
+	return sc
+}
+
+func (t Tracing) start(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = t.Propagator.Extract(ctx, metadataCarrier(md))
+	ctx = context.WithValue(ctx, parentSpanKey{}, trace.SpanContextFromContext(ctx))
+
+	name := strings.TrimPrefix(fullMethod, "/")
+	service, method, _ := strings.Cut(name, "/")
+	return t.Tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.RPCSystemGRPC,
+			semconv.RPCService(service),
+			semconv.RPCMethod(method),
+		))
+}
+
+func endSpan(span trace.Span, err error) {
+	st := status.Convert(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(st.Code())))
+
+	// only these codes are considered as server errors by semantic conventions
+	switch st.Code() {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented,
+		codes.Internal, codes.Unavailable, codes.DataLoss:
+		span.SetStatus(otelcodes.Error, st.Message())
+	}
+
+	span.End()
+}
+
+// metadataCarrier adapts metadata.MD to propagation.TextMapCarrier.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if vals := metadata.MD(c).Get(key); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) { metadata.MD(c).Set(key, value) }
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/Semior001/grpc-echo/echopb"
+	"github.com/Semior001/grpc-echo/echopb/echopbconnect"
+	"github.com/Semior001/grpc-echo/pkg/grpcx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ConnectHandler exposes unary methods of EchoService over the Connect protocol.
+type ConnectHandler struct {
+	echopbconnect.UnimplementedEchoServiceHandler
+	Service *EchoService
+	// Interceptor, if set, is applied to unary calls.
+	Interceptor grpc.UnaryServerInterceptor
+}
+
+// Echo calls EchoService.Echo.
+func (h *ConnectHandler) Echo(
+	ctx context.Context,
+	req *connect.Request[echopb.EchoRequest],
+) (*connect.Response[echopb.EchoResponse], error) {
+	resp, header, trailer, err := unary(ctx, h.Interceptor, h.Service, echopbconnect.EchoServiceEchoProcedure, req.Msg,
+		func(ctx context.Context, req any) (any, error) {
+			return h.Service.Echo(ctx, req.(*echopb.EchoRequest))
+		})
+	if err != nil {
+		cerr := connectError(err)
+		copyMetadata(cerr.Meta(), header)
+		copyMetadata(cerr.Meta(), trailer)
+		return nil, cerr
+	}
+
+	cresp := connect.NewResponse(resp.(*echopb.EchoResponse))
+	copyMetadata(cresp.Header(), header)
+	copyMetadata(cresp.Trailer(), trailer)
+	return cresp, nil
+}
+
+// unary calls the handler through the interceptor, if any, collecting
+// the headers and trailers set by the handler.
+func unary(
+	ctx context.Context,
+	interceptor grpc.UnaryServerInterceptor,
+	srv any,
+	method string,
+	req any,
+	handler grpc.UnaryHandler,
+) (resp any, header, trailer metadata.MD, err error) {
+	stream := &grpcx.MetadataStream{FullMethod: method}
+	ctx = grpc.NewContextWithServerTransportStream(ctx, stream)
+
+	if interceptor != nil {
+		resp, err = interceptor(ctx, req, &grpc.UnaryServerInfo{Server: srv, FullMethod: method}, handler)
+	} else {
+		resp, err = handler(ctx, req)
+	}
+
+	return resp, stream.Header(), stream.Trailer(), err
+}
+
+// connectError converts gRPC status error to Connect error, with details.
+func connectError(err error) *connect.Error {
+	st := status.Convert(err)
+	cerr := connect.NewError(connect.Code(st.Code()), errors.New(st.Message()))
+	for _, d := range st.Proto().Details {
+		msg, err := d.UnmarshalNew()
+		if err != nil {
+			continue
+		}
+		detail, err := connect.NewErrorDetail(msg)
+		if err != nil {
+			continue
+		}
+		cerr.AddDetail(detail)
+	}
+	return cerr
+}
+
+// copyMetadata copies the metadata to HTTP headers,
+// binary values are encoded as Connect protocol requires.
+func copyMetadata(dst http.Header, md metadata.MD) {
+	for k, vals := range md {
+		for _, v := range vals {
+			if strings.HasSuffix(k, "-bin") {
+				v = connect.EncodeBinaryHeader([]byte(v))
+			}
+			dst.Add(k, v)
+		}
+	}
+}
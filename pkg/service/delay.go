@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/Semior001/grpc-echo/echopb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// validateDelay checks that min and max of the requested delay are
+// valid non-negative durations, representable as time.Duration.
+func validateDelay(req *echopb.Delay) error {
+	for _, d := range []struct {
+		name string
+		val  *durationpb.Duration
+	}{{"min", req.Min}, {"max", req.Max}} {
+		if d.val == nil {
+			continue
+		}
+		if err := d.val.CheckValid(); err != nil {
+			return fmt.Errorf("invalid %s: %w", d.name, err)
+		}
+		if d.val.AsDuration() < 0 {
+			return fmt.Errorf("%s must not be negative", d.name)
+		}
+		// AsDuration saturates on overflow
+		if sat := durationpb.New(d.val.AsDuration()); sat.Seconds != d.val.Seconds || sat.Nanos != d.val.Nanos {
+			return fmt.Errorf("%s is too large", d.name)
+		}
+	}
+	return nil
+}
+
+// requestedDelay returns the duration to wait according to the requested delay,
+// which must be validated with validateDelay.
+func requestedDelay(req *echopb.Delay) time.Duration {
+	lo, hi := req.Min.AsDuration(), req.Max.AsDuration()
+	if hi <= lo {
+		return lo
+	}
+
+	switch req.Distribution {
+	case echopb.Delay_DISTRIBUTION_NORMAL:
+		mean, stddev := float64(lo+(hi-lo)/2), float64(hi-lo)/6
+		d := mean + rand.NormFloat64()*stddev //nolint:gosec // no need for crypto rand
+		// clamp before the conversion, as it overflows silently
+		switch {
+		case d <= float64(lo):
+			return lo
+		case d >= float64(hi):
+			return hi
+		}
+		return time.Duration(d)
+	default:
+		return lo + rand.N(hi-lo) //nolint:gosec // no need for crypto rand
+	}
+}
+
+// sleep waits for the given duration or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -10,27 +10,227 @@ import (
 	"time"
 	"context"
 	"github.com/Semior001/grpc-echo/pkg/grpcx"
+	"google.golang.org/grpc/status"
+	"errors"
+	"io"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"go.opentelemetry.io/otel/trace"
+	"unicode/utf8"
 )
 
 // EchoService implements the EchoServiceServer interface.
-type EchoService struct{ echopb.UnimplementedEchoServiceServer }
+type EchoService struct {
+	echopb.UnimplementedEchoServiceServer
+	// RealIP resolves the remote address of the client.
+	RealIP grpcx.RealIPResolver
+}
 
-// Echo returns the request as a response with some additional timestamps.
+// Echo returns the request as a response with some additional timestamps,
+// along with the requested response headers and trailers.
+// If the request specifies a delay, Echo waits for it before responding.
+// If the request specifies a status, Echo fails with it instead.
 func (s *EchoService) Echo(ctx context.Context, req *echopb.EchoRequest) (resp *echopb.EchoResponse, err error) {
+	reachedAt := time.Now()
+
+	if req.Delay != nil {
+		if err = validateDelay(req.Delay); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid delay: %v", err)
+		}
+	}
+
+	if len(req.ResponseHeaders) > 0 {
+		if err = grpc.SetHeader(ctx, metadata.New(req.ResponseHeaders)); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "set response headers: %v", err)
+		}
+	}
+
+	if len(req.ResponseTrailers) > 0 {
+		if err = grpc.SetTrailer(ctx, metadata.New(req.ResponseTrailers)); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "set response trailers: %v", err)
+		}
+	}
+
+	var delay time.Duration
+	if req.Delay != nil {
+		if err = sleep(ctx, requestedDelay(req.Delay)); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+		delay = time.Since(reachedAt)
+	}
+
+	if req.Status != nil {
+		st, err := requestedStatus(req.Status)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid status: %v", err)
+		}
+		if err = st.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	resp = s.echo(ctx, req.Ping, reachedAt)
+	if req.Delay != nil {
+		resp.Delay = durationpb.New(delay)
+	}
+	defer func() { resp.HandlerRespondedAt = timestamppb.Now() }()
+	return resp, nil
+}
+
+// EchoStream sends the echo response the requested number of times
+// with the requested interval, until the stream is closed. Unbounded
+// streams require a positive interval.
+func (s *EchoService) EchoStream(req *echopb.EchoStreamRequest, stream echopb.EchoService_EchoStreamServer) error {
+	ctx := stream.Context()
+	recvAt := time.Now()
+
+	interval := req.Interval.AsDuration()
+	if interval < 0 {
+		return status.Error(codes.InvalidArgument, "interval must not be negative")
+	}
+	if req.Count == 0 && interval == 0 {
+		return status.Error(codes.InvalidArgument, "interval must be positive for unbounded stream")
+	}
+
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for seq := uint64(1); req.Count == 0 || seq <= uint64(req.Count); seq++ {
+		if seq > 1 && tick != nil {
+			select {
+			case <-ctx.Done():
+				return status.FromContextError(ctx.Err()).Err()
+			case <-tick:
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+
+		resp := s.echo(ctx, req.Ping, time.Now())
+		resp.Seq = seq
+		resp.ReceivedAt = timestamppb.New(recvAt)
+		resp.HandlerRespondedAt = timestamppb.Now()
+		resp.SentAt = timestamppb.Now()
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Collect receives all pings from the client and responds with them
+// once the client closes the stream.
+func (*EchoService) Collect(stream echopb.EchoService_CollectServer) error {
+	resp := &echopb.CollectResponse{}
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(resp)
+		}
+		if err != nil {
+			return err
+		}
+
+		now := timestamppb.Now()
+		if resp.FirstReceivedAt == nil {
+			resp.FirstReceivedAt = now
+		}
+		resp.LastReceivedAt = now
+		resp.Pings = append(resp.Pings, req.Ping)
+		resp.Count++
+		resp.TotalBytes += uint64(len(req.Ping))
+	}
+}
+
+// EchoBidi responds to each received ping right away.
+func (s *EchoService) EchoBidi(stream echopb.EchoService_EchoBidiServer) error {
+	for seq := uint64(1); ; seq++ {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		recvAt := timestamppb.Now()
+		resp := s.echo(stream.Context(), req.Ping, time.Now())
+		resp.Seq = seq
+		resp.ReceivedAt = recvAt
+		resp.HandlerRespondedAt = timestamppb.Now()
+		resp.SentAt = timestamppb.Now()
+		if err = stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// echo makes a response with the request metadata, the remote address,
+// the TLS connection details and the deadline of the call, as it was
+// when the handler was reached. The deadline is the one set by the client,
+// the stream timeout of the server is not reported.
+func (s *EchoService) echo(ctx context.Context, ping string, reachedAt time.Time) *echopb.EchoResponse {
 	md, _ := metadata.FromIncomingContext(ctx)
-	resp = &echopb.EchoResponse{
+	resp := &echopb.EchoResponse{
 		Headers:          make(map[string]string, len(md)),
-		Body:             req.Ping,
-		HandlerReachedAt: timestamppb.Now(),
+		Metadata:         make(map[string]*echopb.MetadataValues, len(md)),
+		Body:             ping,
+		HandlerReachedAt: timestamppb.New(reachedAt),
+		Tls:              tlsInfo(ctx),
 	}
-	if ip, err := grpcx.RealIP(ctx); err == nil {
+	if deadline, ok := grpcx.ClientDeadline(ctx); ok {
+		remaining := deadline.Sub(reachedAt)
+		resp.Deadline = timestamppb.New(deadline)
+		resp.DeadlineRemaining = durationpb.New(remaining)
+	}
+	resp.GrpcTimeout = grpcx.GRPCTimeout(ctx)
+	if ip, err := s.RealIP.RealIP(ctx); err == nil {
 		resp.RemoteAddr = ip
 	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		resp.Trace = &echopb.TraceContext{
+			TraceId:    sc.TraceID().String(),
+			SpanId:     sc.SpanID().String(),
+			Sampled:    sc.IsSampled(),
+			TraceState: sc.TraceState().String(),
+		}
+		if parent := grpcx.ParentSpanContext(ctx); parent.IsValid() {
+			resp.Trace.ParentSpanId = parent.SpanID().String()
+		}
+	}
+	for _, hop := range grpcx.Forwarded(md) {
+		resp.Forwarded = append(resp.Forwarded, &echopb.ForwardedHop{
+			For:     hop.For,
+			By:      hop.By,
+			Proto:   hop.Proto,
+			Host:    hop.Host,
+			Private: hop.Private,
+		})
+	}
 	for k, vals := range md {
-		resp.Headers[k] = strings.Join(vals, ",")
+		mv := &echopb.MetadataValues{}
+		if strings.HasSuffix(k, "-bin") {
+			for _, v := range vals {
+				mv.BinaryValues = append(mv.BinaryValues, []byte(v))
+			}
+		} else {
+			mv.Values = append(mv.Values, vals...)
+		}
+		resp.Metadata[k] = mv
+		// binary values, which are not valid strings, can't be marshaled,
+		// thus they are available only in metadata
+		if joined := strings.Join(vals, ","); utf8.ValidString(joined) {
+			resp.Headers[k] = joined
+		}
 	}
-	defer func() { resp.HandlerRespondedAt = timestamppb.Now() }()
-	return resp, nil
+	return resp
 }
 
 // AppendTimestampInterceptor appends timestamps to the echo response.
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/Semior001/grpc-echo/echopb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// RESTHandler exposes EchoService.Echo as an HTTP/JSON endpoint with protojson bodies.
+type RESTHandler struct {
+	Service *EchoService
+	// Interceptor, if set, is applied to calls.
+	Interceptor grpc.UnaryServerInterceptor
+	// MaxBodySize limits the size of the request body, 0 means no limit.
+	MaxBodySize int64
+}
+
+// ServeHTTP handles the echo request.
+func (h *RESTHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body := io.Reader(r.Body)
+	if h.MaxBodySize > 0 {
+		body = http.MaxBytesReader(w, r.Body, h.MaxBodySize)
+	}
+
+	b, err := io.ReadAll(body)
+	if maxErr := (*http.MaxBytesError)(nil); errors.As(err, &maxErr) {
+		writeREST(w, http.StatusRequestEntityTooLarge,
+			status.Newf(codes.ResourceExhausted, "request body exceeds %d bytes", maxErr.Limit).Proto())
+		return
+	}
+	if err != nil {
+		writeRESTError(w, status.Errorf(codes.InvalidArgument, "read body: %v", err))
+		return
+	}
+
+	req := &echopb.EchoRequest{}
+	if len(b) > 0 {
+		if err = protojson.Unmarshal(b, req); err != nil {
+			writeRESTError(w, status.Errorf(codes.InvalidArgument, "unmarshal request: %v", err))
+			return
+		}
+	}
+
+	resp, header, trailer, err := unary(r.Context(), h.Interceptor, h.Service, echopb.EchoService_Echo_FullMethodName, req,
+		func(ctx context.Context, req any) (any, error) {
+			return h.Service.Echo(ctx, req.(*echopb.EchoRequest))
+		})
+
+	copyMetadata(w.Header(), header)
+	copyMetadata(w.Header(), prefixed(http.TrailerPrefix, trailer))
+
+	if err != nil {
+		writeRESTError(w, err)
+		return
+	}
+
+	writeREST(w, http.StatusOK, resp.(*echopb.EchoResponse))
+}
+
+// writeRESTError writes the gRPC status as JSON with the corresponding HTTP code.
+func writeRESTError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	writeREST(w, httpStatus(st.Code()), st.Proto())
+}
+
+func writeREST(w http.ResponseWriter, code int, msg proto.Message) {
+	b, err := protojson.Marshal(msg)
+	if err != nil {
+		http.Error(w, "marshal response: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(b)
+}
+
+// prefixed returns a copy of the metadata with the prefix prepended to the keys.
+func prefixed(prefix string, md metadata.MD) metadata.MD {
+	res := make(metadata.MD, len(md))
+	for k, v := range md {
+		res[prefix+k] = v
+	}
+	return res
+}
+
+// httpStatus maps gRPC code to HTTP status, in the same way as grpc-gateway does.
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499 // client closed request
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default: // Unknown, Internal, DataLoss
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/Semior001/grpc-echo/echopb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// requestedStatus makes a status from the requested one,
+// with the requested details attached.
+func requestedStatus(req *echopb.Status) (*status.Status, error) {
+	st := status.New(codes.Code(req.Code), req.Message)
+	if len(req.Details) == 0 {
+		return st, nil
+	}
+
+	details := make([]protoadapt.MessageV1, 0, len(req.Details))
+	for idx, d := range req.Details {
+		switch d := d.Detail.(type) {
+		case *echopb.StatusDetail_RetryInfo:
+			details = append(details, &errdetails.RetryInfo{RetryDelay: d.RetryInfo.RetryDelay})
+		case *echopb.StatusDetail_BadRequest:
+			br := &errdetails.BadRequest{}
+			for _, v := range d.BadRequest.FieldViolations {
+				br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+					Field:       v.Field,
+					Description: v.Description,
+				})
+			}
+			details = append(details, br)
+		case *echopb.StatusDetail_ErrorInfo:
+			details = append(details, &errdetails.ErrorInfo{
+				Reason:   d.ErrorInfo.Reason,
+				Domain:   d.ErrorInfo.Domain,
+				Metadata: d.ErrorInfo.Metadata,
+			})
+		default:
+			return nil, fmt.Errorf("detail #%d is empty", idx)
+		}
+	}
+
+	st, err := st.WithDetails(details...)
+	if err != nil {
+		return nil, fmt.Errorf("attach details: %w", err)
+	}
+
+	return st, nil
+}
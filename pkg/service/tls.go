@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+
+	"github.com/Semior001/grpc-echo/echopb"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// tlsInfo returns the TLS connection details of the peer,
+// or nil, if the connection is not secured.
+func tlsInfo(ctx context.Context) *echopb.TLSInfo {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	ai, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil
+	}
+
+	info := &echopb.TLSInfo{
+		Version:        tls.VersionName(ai.State.Version),
+		CipherSuite:    tls.CipherSuiteName(ai.State.CipherSuite),
+		Alpn:           ai.State.NegotiatedProtocol,
+		ServerName:     ai.State.ServerName,
+		DidResume:      ai.State.DidResume,
+		ClientVerified: len(ai.State.VerifiedChains) > 0,
+	}
+
+	if len(ai.State.PeerCertificates) > 0 {
+		info.ClientCertificate = certificate(ai.State.PeerCertificates[0])
+	}
+
+	return info
+}
+
+func certificate(cert *x509.Certificate) *echopb.Certificate {
+	res := &echopb.Certificate{
+		Subject:        cert.Subject.String(),
+		Issuer:         cert.Issuer.String(),
+		DnsNames:       cert.DNSNames,
+		EmailAddresses: cert.EmailAddresses,
+	}
+	for _, ip := range cert.IPAddresses {
+		res.IpAddresses = append(res.IpAddresses, ip.String())
+	}
+	for _, u := range cert.URIs {
+		res.Uris = append(res.Uris, u.String())
+	}
+	return res
+}
@@ -0,0 +1,96 @@
+// Package tlsx provides helpers to work with TLS certificates.
+package tlsx
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// CertReloader keeps the certificate loaded from the cert and key files
+// and reloads it on demand. It serves the certificate via GetCertificate,
+// so that new handshakes use the latest loaded certificate, while already
+// established connections keep working.
+type CertReloader struct {
+	certFile, keyFile string
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+// NewCertReloader makes a new CertReloader and loads the certificate.
+func NewCertReloader(certFile, keyFile string) (*CertReloader, error) {
+	r := &CertReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload loads the certificate from the files. In case of error,
+// the previously loaded certificate is kept.
+func (r *CertReloader) Reload() error {
+	modTime, err := r.lastModified()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("load cert and key: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert, r.modTime = &cert, modTime
+	return nil
+}
+
+// ReloadIfModified reloads the certificate if any of the files
+// was modified since the last load.
+func (r *CertReloader) ReloadIfModified() (reloaded bool, err error) {
+	modTime, err := r.lastModified()
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	modified := !modTime.Equal(r.modTime)
+	r.mu.RUnlock()
+
+	if !modified {
+		return false, nil
+	}
+
+	if err = r.Reload(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetCertificate returns the last loaded certificate,
+// it's supposed to be used as tls.Config.GetCertificate.
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// lastModified returns the latest modification time among the files.
+func (r *CertReloader) lastModified() (time.Time, error) {
+	var res time.Time
+	for _, path := range []string{r.certFile, r.keyFile} {
+		fi, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("stat %s: %w", path, err)
+		}
+		if fi.ModTime().After(res) {
+			res = fi.ModTime()
+		}
+	}
+	return res, nil
+}